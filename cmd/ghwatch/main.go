@@ -4,28 +4,111 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/nateberkopec/ghwatch/internal/app"
 	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+	"github.com/nateberkopec/ghwatch/internal/gitrepo"
+	"github.com/nateberkopec/ghwatch/internal/persistence"
 )
 
 func main() {
 	var (
 		pollInterval time.Duration
 		bellEnabled  bool
+		maxPages     int
+		persistCache bool
+		hosts        []string
+		allowWrite   bool
+		concurrency  int
+		timeout      time.Duration
+		useGraphQL   bool
+		maxRetries   int
+		defaultRepo  string
+		watchHere    bool
+		discoverPRs  bool
+		discoverTick time.Duration
+		archiveOld   bool
+		groupRuns    bool
 	)
 
-	flag.DurationVar(&pollInterval, "interval", 10*time.Second, "how often to refresh watched runs")
+	flag.DurationVar(&pollInterval, "interval", 10*time.Second, "base refresh interval; each run is polled faster or slower depending on its progress")
 	flag.BoolVar(&bellEnabled, "bell", true, "ring the terminal bell when a run state changes")
+	flag.IntVar(&maxPages, "max-pages", githubclient.DefaultMaxPages, "maximum pages to fetch per listing (runs per commit, PR searches, Actions feeds)")
+	flag.IntVar(&maxRetries, "retries", githubclient.DefaultMaxRetries, "retries for 5xx responses, timeouts and dropped connections")
+	flag.BoolVar(&persistCache, "persist-cache", true, "keep the GitHub ETag cache on disk between sessions")
+	flag.IntVar(&concurrency, "concurrency", 4, "maximum GitHub requests in flight during a refresh")
+	flag.DurationVar(&timeout, "request-timeout", 15*time.Second, "timeout for each refresh request")
+	flag.BoolVar(&useGraphQL, "graphql", false, "check runs and PR heads in batched GraphQL queries, re-fetching only what changed")
+	flag.StringVar(&defaultRepo, "repo", "", "repository for #123 and run ID short forms (owner/repo or host/owner/repo; defaults to the current git checkout's remote)")
+	flag.BoolVar(&watchHere, "here", false, "watch the current checkout on startup: its branch's open PR, or else its HEAD commit")
+	flag.BoolVar(&discoverPRs, "discover-prs", false, "watch open PRs you authored or were asked to review, archiving them once closed (needs a token)")
+	flag.DurationVar(&discoverTick, "discover-interval", 2*time.Minute, "how often -discover-prs searches for PRs")
+	flag.BoolVar(&archiveOld, "archive-superseded", false, "archive PR runs once a push or force-push moves the PR to a new head")
+	flag.BoolVar(&groupRuns, "group", false, "start with runs grouped by PR, commit or branch (toggle with v)")
+	flag.BoolVar(&allowWrite, "allow-write", false, "enable rerun/cancel key bindings (token needs actions:write)")
+	flag.Func("enterprise-host", "GitHub Enterprise Server host to accept, as host or host=token (repeatable or comma-separated; otherwise the token comes from GH_ENTERPRISE_TOKEN_<HOST> or GH_ENTERPRISE_TOKEN)", func(value string) error {
+		hosts = append(hosts, splitHosts(value)...)
+		return nil
+	})
 	flag.Parse()
+	if len(hosts) == 0 {
+		hosts = splitHosts(os.Getenv("GHWATCH_ENTERPRISE_HOSTS"))
+	}
+	hostTokens := make(map[string]string, len(hosts))
+	for i, host := range hosts {
+		if name, token, ok := strings.Cut(host, "="); ok {
+			hosts[i] = strings.TrimSpace(name)
+			hostTokens[hosts[i]] = strings.TrimSpace(token)
+		}
+	}
+	githuburl.SetEnterpriseHosts(hosts...)
+	if err := setDefaultRepo(defaultRepo); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+
+	client := githubclient.New("")
+	clients := []*githubclient.Client{client}
+	hostClients := make(map[string]*githubclient.Client, len(hosts))
+	for _, host := range hosts {
+		hostClient := githubclient.NewEnterprise(host, hostTokens[host])
+		hostClients[hostClient.Host()] = hostClient
+		clients = append(clients, hostClient)
+	}
+
+	var cachedEntries []githubclient.CacheEntry
+	if persistCache {
+		cachedEntries, _ = persistence.LoadHTTPCache()
+	}
+	for _, c := range clients {
+		c.SetMaxPages(maxPages)
+		c.SetMaxRetries(maxRetries)
+		c.SetAllowWrite(allowWrite)
+		c.ImportCache(cachedEntries)
+	}
 
 	cfg := app.Config{
-		Client:       githubclient.New(""),
+		Client:       client,
+		HostClients:  hostClients,
 		PollInterval: pollInterval,
 		BellEnabled:  bellEnabled,
+		AllowWrite:   allowWrite,
+		GraphQL:      useGraphQL,
+		WatchHere:    watchHere,
+
+		DiscoverPRs:      discoverPRs,
+		DiscoverInterval: discoverTick,
+
+		ArchiveSuperseded: archiveOld,
+		GroupBySource:     groupRuns,
+
+		Concurrency:    concurrency,
+		RequestTimeout: timeout,
 	}
 
 	program := tea.NewProgram(
@@ -34,8 +117,53 @@ func main() {
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := program.Run(); err != nil {
+	_, err := program.Run()
+	if persistCache {
+		var entries []githubclient.CacheEntry
+		for _, c := range clients {
+			entries = append(entries, c.ExportCache()...)
+		}
+		persistence.SaveHTTPCache(entries)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func splitHosts(value string) []string {
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// setDefaultRepo configures the repository short forms like #123 refer to:
+// the --repo value, or else the GitHub remote of the current directory's
+// checkout, if there is one.
+func setDefaultRepo(value string) error {
+	if value != "" {
+		host, owner, repo, err := githuburl.ParseRepo(value)
+		if err != nil {
+			return err
+		}
+		githuburl.SetDefaultRepo(host, owner, repo)
+		return nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	repo, err := gitrepo.Find(dir)
+	if err != nil {
+		return nil
+	}
+	if host, owner, name, err := repo.GitHubRepo(); err == nil {
+		githuburl.SetDefaultRepo(host, owner, name)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/watch"
+)
+
+// runAction is a write operation waiting for the user to confirm it.
+type runAction struct {
+	prompt string
+	label  string
+	runID  int64
+	call   func(ctx context.Context) error
+}
+
+type actionResultMsg struct {
+	RunID int64
+	Label string
+	Err   error
+}
+
+// requestAction stages a write operation against the selected run and asks
+// for confirmation in the status line.
+func (m *Model) requestAction(kind string) {
+	if !m.allowWrite {
+		m.setStatus("Write actions are disabled; restart with --allow-write", statusNeutral)
+		return
+	}
+	run := m.selectedRun()
+	if run == nil {
+		return
+	}
+	owner, repo := splitRepo(run.Run.RepoFullName)
+	if owner == "" {
+		return
+	}
+	client := m.clientFor(run.Run.Host)
+	runID := run.Run.ID
+	name := runLabel(run.Run)
+
+	action := &runAction{runID: runID}
+	switch kind {
+	case "rerun":
+		action.prompt = fmt.Sprintf("Re-run all jobs of %s • %s?", name, run.Run.Name)
+		action.label = "Re-run requested"
+		action.call = func(ctx context.Context) error { return client.RerunRun(ctx, owner, repo, runID) }
+	case "rerun-failed":
+		action.prompt = fmt.Sprintf("Re-run failed jobs of %s • %s?", name, run.Run.Name)
+		action.label = "Re-run of failed jobs requested"
+		action.call = func(ctx context.Context) error { return client.RerunFailedJobs(ctx, owner, repo, runID) }
+	case "cancel":
+		action.prompt = fmt.Sprintf("Cancel %s • %s?", name, run.Run.Name)
+		action.label = "Cancellation requested"
+		action.call = func(ctx context.Context) error { return client.CancelRun(ctx, owner, repo, runID) }
+	default:
+		return
+	}
+	m.confirm = action
+}
+
+// resolveConfirm runs the staged action on "y" and drops it on anything else.
+func (m *Model) resolveConfirm(key string) tea.Cmd {
+	action := m.confirm
+	m.confirm = nil
+	if key != "y" && key != "Y" {
+		m.setStatus("Cancelled", statusNeutral)
+		return nil
+	}
+	m.setStatus(fmt.Sprintf("%s…", action.label), statusNeutral)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return actionResultMsg{RunID: action.runID, Label: action.label, Err: action.call(ctx)}
+	}
+}
+
+// refreshRunCmd re-fetches a single run right away so a rerun's new attempt
+// (or a cancellation) shows up without waiting for the next poll.
+func (m *Model) refreshRunCmd(run *watch.TrackedRun) tea.Cmd {
+	owner, repo := splitRepo(run.Run.RepoFullName)
+	if owner == "" {
+		return nil
+	}
+	client := m.clientFor(run.Run.Host)
+	runID := run.Run.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		refreshed, err := client.WorkflowRunByID(ctx, owner, repo, runID)
+		if err != nil {
+			return refreshResultMsg{Err: err}
+		}
+		return refreshResultMsg{Runs: []githubclient.WorkflowRun{refreshed}}
+	}
+}
+
+func (m *Model) absorbActionResult(msg actionResultMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.noteRateLimit(msg.Err)
+		m.setStatus(fmt.Sprintf("%s failed: %v", msg.Label, msg.Err), statusError)
+		return nil
+	}
+	m.setStatus(msg.Label, statusSuccess)
+	if run := m.tracker.Get(msg.RunID); run != nil {
+		// GitHub can take a few seconds to show a new attempt or the
+		// cancellation, so one refresh isn't enough.
+		m.followUpRun(run, time.Now())
+		return m.refreshRunCmd(run)
+	}
+	return nil
+}
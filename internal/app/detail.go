@@ -0,0 +1,270 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/watch"
+)
+
+type detailMode int
+
+const (
+	detailJobs detailMode = iota
+	detailAnnotations
+)
+
+// detailState backs the expandable pane that lists either the jobs and steps
+// or the check annotations of the selected run. It follows the selection and
+// refreshes at most once per poll interval while the run is still going.
+type detailState struct {
+	open     bool
+	mode     detailMode
+	runID    int64
+	err      error
+	loading  bool
+	jobs     []githubclient.Job
+	jobIndex int
+	// focusJobID is selected once the jobs load, e.g. when the run was
+	// added from a job or check run link.
+	focusJobID int64
+
+	annotations     []githubclient.Annotation
+	annotationIndex int
+
+	// fetchedAt is when the pane last asked for jobs or annotations.
+	fetchedAt time.Time
+}
+
+type jobsResultMsg struct {
+	RunID int64
+	Jobs  []githubclient.Job
+	Err   error
+}
+
+type annotationsResultMsg struct {
+	RunID       int64
+	Annotations []githubclient.Annotation
+	Err         error
+}
+
+type clipboardResultMsg struct {
+	Text string
+	Err  error
+}
+
+func (m *Model) toggleDetail() tea.Cmd {
+	m.detail.open = !m.detail.open
+	m.configureLayout()
+	m.ensureSelectionBounds()
+	if !m.detail.open {
+		m.detail = detailState{}
+		return nil
+	}
+	return m.syncDetail()
+}
+
+// toggleAnnotations flips the pane between jobs and annotations, opening it
+// first when needed.
+func (m *Model) toggleAnnotations() tea.Cmd {
+	mode := detailAnnotations
+	if m.detail.open && m.detail.mode == detailAnnotations {
+		mode = detailJobs
+	}
+	wasOpen := m.detail.open
+	m.detail = detailState{open: true, mode: mode}
+	if !wasOpen {
+		m.configureLayout()
+		m.ensureSelectionBounds()
+	}
+	return m.syncDetail()
+}
+
+// syncDetail starts loading jobs when the pane is open and the selection moved
+// to a different run.
+func (m *Model) syncDetail() tea.Cmd {
+	if !m.detail.open {
+		return nil
+	}
+	run := m.selectedRun()
+	if run == nil {
+		m.detail = detailState{open: true, mode: m.detail.mode}
+		return nil
+	}
+	if run.Run.ID == m.detail.runID {
+		return nil
+	}
+	m.detail = detailState{open: true, mode: m.detail.mode, runID: run.Run.ID, loading: true}
+	return m.fetchDetailCmd(run)
+}
+
+// refreshDetailCmd reloads the jobs for the run currently in the pane. Unless
+// forced, finished runs are left alone and the pane is reloaded at most once
+// per poll interval.
+func (m *Model) refreshDetailCmd(force bool) tea.Cmd {
+	if !m.detail.open || m.detail.runID == 0 {
+		return nil
+	}
+	run := m.selectedRun()
+	if run == nil || run.Run.ID != m.detail.runID {
+		return nil
+	}
+	if !force && (!m.polled(run) || time.Since(m.detail.fetchedAt) < m.pollInterval) {
+		return nil
+	}
+	return m.fetchDetailCmd(run)
+}
+
+func (m *Model) fetchDetailCmd(run *watch.TrackedRun) tea.Cmd {
+	owner, repo := splitRepo(run.Run.RepoFullName)
+	if owner == "" {
+		return nil
+	}
+	client := m.clientFor(run.Run.Host)
+	runID := run.Run.ID
+	m.detail.fetchedAt = time.Now()
+	if m.detail.mode == detailAnnotations {
+		suiteID := run.Run.CheckSuiteID
+		return func() tea.Msg {
+			if suiteID == 0 {
+				return annotationsResultMsg{RunID: runID, Err: fmt.Errorf("no check suite recorded for this run yet")}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			annotations, err := client.AnnotationsForCheckSuite(ctx, owner, repo, suiteID)
+			return annotationsResultMsg{RunID: runID, Annotations: annotations, Err: err}
+		}
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		jobs, err := client.JobsForRun(ctx, owner, repo, runID)
+		return jobsResultMsg{RunID: runID, Jobs: jobs, Err: err}
+	}
+}
+
+func (m *Model) absorbJobs(msg jobsResultMsg) {
+	if !m.detail.open || m.detail.mode != detailJobs || msg.RunID != m.detail.runID {
+		return
+	}
+	m.detail.loading = false
+	m.detail.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	var selectedID int64
+	if job := m.selectedJob(); job != nil {
+		selectedID = job.ID
+	}
+	if m.detail.focusJobID != 0 {
+		selectedID = m.detail.focusJobID
+		m.detail.focusJobID = 0
+	}
+	m.detail.jobs = msg.Jobs
+	m.detail.jobIndex = defaultJobIndex(msg.Jobs)
+	for i, job := range msg.Jobs {
+		if selectedID != 0 && job.ID == selectedID {
+			m.detail.jobIndex = i
+		}
+	}
+}
+
+// focusJob selects a run in the list and opens the detail pane on one of its
+// jobs.
+func (m *Model) focusJob(runID, jobID int64) tea.Cmd {
+	m.showArchived = false
+	m.selectRun(runID)
+	wasOpen := m.detail.open
+	m.detail = detailState{open: true, mode: detailJobs}
+	if !wasOpen {
+		m.configureLayout()
+	}
+	m.ensureSelectionBounds()
+	cmd := m.syncDetail()
+	m.detail.focusJobID = jobID
+	return cmd
+}
+
+func (m *Model) absorbAnnotations(msg annotationsResultMsg) {
+	if !m.detail.open || m.detail.mode != detailAnnotations || msg.RunID != m.detail.runID {
+		return
+	}
+	m.detail.loading = false
+	m.detail.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	m.detail.annotations = msg.Annotations
+	m.detail.annotationIndex = clampIndex(m.detail.annotationIndex, len(msg.Annotations))
+}
+
+// moveDetail moves the highlighted job or annotation, depending on the mode.
+func (m *Model) moveDetail(delta int) {
+	if m.detail.mode == detailAnnotations {
+		m.detail.annotationIndex = clampIndex(m.detail.annotationIndex+delta, len(m.detail.annotations))
+		return
+	}
+	m.detail.jobIndex = clampIndex(m.detail.jobIndex+delta, len(m.detail.jobs))
+}
+
+func clampIndex(index, length int) int {
+	if index >= length {
+		index = length - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
+
+func (m *Model) selectedAnnotation() *githubclient.Annotation {
+	if m.detail.mode != detailAnnotations {
+		return nil
+	}
+	if m.detail.annotationIndex < 0 || m.detail.annotationIndex >= len(m.detail.annotations) {
+		return nil
+	}
+	return &m.detail.annotations[m.detail.annotationIndex]
+}
+
+// copyAnnotationLocation puts the selected annotation's file:line on the
+// system clipboard.
+func (m *Model) copyAnnotationLocation() tea.Cmd {
+	annotation := m.selectedAnnotation()
+	if annotation == nil {
+		m.setStatus("Open annotations (n) and pick one to copy its location", statusNeutral)
+		return nil
+	}
+	text := annotation.Location()
+	return func() tea.Msg {
+		return clipboardResultMsg{Text: text, Err: clipboard.WriteAll(text)}
+	}
+}
+
+func (m *Model) selectedJob() *githubclient.Job {
+	if m.detail.jobIndex < 0 || m.detail.jobIndex >= len(m.detail.jobs) {
+		return nil
+	}
+	return &m.detail.jobs[m.detail.jobIndex]
+}
+
+// defaultJobIndex points the pane at the job most likely to be interesting:
+// the first failure, otherwise the first job still running.
+func defaultJobIndex(jobs []githubclient.Job) int {
+	for i, job := range jobs {
+		if job.Status == githubclient.RunStatusFailed {
+			return i
+		}
+	}
+	for i, job := range jobs {
+		if job.Status == githubclient.RunStatusPending {
+			return i
+		}
+	}
+	return 0
+}
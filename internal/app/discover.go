@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+	"github.com/nateberkopec/ghwatch/internal/persistence"
+)
+
+const defaultDiscoverInterval = 2 * time.Minute
+
+// discoverQueries find the pull requests the token's user authored or was
+// asked to review.
+var discoverQueries = []string{
+	"is:pr is:open author:@me",
+	"is:pr is:open review-requested:@me",
+}
+
+type discoverTickMsg struct{}
+
+type discoverResultMsg struct {
+	// Open is every discovered PR still open, including ones that dropped out
+	// of the search (e.g. after the review was submitted).
+	Open []githuburl.Parsed
+	// Added holds the runs of PRs that were not discovered before.
+	Added []fetchOutcome
+	// Closed PRs were discovered before and have since closed or merged.
+	Closed []githuburl.Parsed
+	// Truncated is set when a search hit the page cap, so some open PRs
+	// may be missing.
+	Truncated bool
+	Err       error
+}
+
+// scheduleDiscover waits for the next discovery round: the usual interval,
+// or until the search quota resets when err says it ran out. A rate limit
+// here only delays discovery; polling of watched runs carries on.
+func (m *Model) scheduleDiscover(err error) tea.Cmd {
+	delay := m.discoverInterval
+	var rateErr *githubclient.RateLimitError
+	if errors.As(err, &rateErr) {
+		if wait := time.Until(rateErr.RetryAt); wait > delay {
+			delay = wait
+		}
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return discoverTickMsg{}
+	})
+}
+
+// discoverCmd searches every configured host for open PRs, fetches runs for
+// the new ones and checks whether the ones that disappeared were closed.
+//
+// PRs the tracker already has runs for count as known, so after a restart
+// open PRs aren't fetched again, archived ones aren't revived, and the ones
+// that closed in the meantime still get archived.
+func (m *Model) discoverCmd() tea.Cmd {
+	clients := map[string]githubAPI{githuburl.DefaultHost: m.client}
+	for host, client := range m.hostClients {
+		clients[host] = client
+	}
+	known := make(map[string]githuburl.Parsed, len(m.discovered))
+	for key, pr := range m.discovered {
+		known[key] = pr
+	}
+	// Only PRs with active runs have anything left to archive.
+	active := make(map[string]bool)
+	for _, archived := range []bool{false, true} {
+		for _, run := range m.tracker.VisibleRuns(archived) {
+			if run.Source.Kind != githuburl.KindPullRequest {
+				continue
+			}
+			key := run.Source.Key()
+			known[key] = run.Source
+			if !archived {
+				active[key] = true
+			}
+		}
+	}
+	concurrency := m.concurrency
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var (
+			msg  discoverResultMsg
+			errs []error
+		)
+		found := map[string]githuburl.Parsed{}
+		searched := map[string]bool{}
+		for host, client := range clients {
+			prs, truncated, err := searchPullRequests(ctx, client, host)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", host, err))
+				continue
+			}
+			searched[host] = true
+			msg.Truncated = msg.Truncated || truncated
+			for _, pr := range prs {
+				found[pr.Key()] = pr
+			}
+		}
+
+		for key, pr := range found {
+			msg.Open = append(msg.Open, pr)
+			if _, ok := known[key]; !ok {
+				msg.Added = append(msg.Added, fetchOutcome{Label: pr.String(), Source: pr, Client: clients[pr.Host]})
+			}
+		}
+		for key, pr := range known {
+			if _, ok := found[key]; ok || !active[key] {
+				continue
+			}
+			if !searched[pr.Host] {
+				msg.Open = append(msg.Open, pr)
+				continue
+			}
+			open, err := clients[pr.Host].PullRequestOpen(ctx, pr.Owner, pr.Repo, pr.PRNumber)
+			if err != nil {
+				// Check again next round.
+				errs = append(errs, fmt.Errorf("%s: %w", pr.String(), err))
+				msg.Open = append(msg.Open, pr)
+				continue
+			}
+			if open {
+				msg.Open = append(msg.Open, pr)
+			} else {
+				msg.Closed = append(msg.Closed, pr)
+			}
+		}
+
+		fetchOutcomes(msg.Added, concurrency)
+		msg.Err = errors.Join(errs...)
+		return msg
+	}
+}
+
+func searchPullRequests(ctx context.Context, client githubAPI, host string) ([]githuburl.Parsed, bool, error) {
+	var (
+		prs       []githuburl.Parsed
+		truncated bool
+	)
+	for _, query := range discoverQueries {
+		refs, cut, err := client.SearchPullRequests(ctx, query)
+		if err != nil {
+			return nil, false, err
+		}
+		truncated = truncated || cut
+		for _, ref := range refs {
+			prs = append(prs, githuburl.Parsed{
+				Kind:     githuburl.KindPullRequest,
+				Host:     host,
+				Owner:    ref.Owner,
+				Repo:     ref.Repo,
+				PRNumber: ref.Number,
+				RawURL:   fmt.Sprintf("https://%s/%s/%s/pull/%d", host, ref.Owner, ref.Repo, ref.Number),
+			})
+		}
+	}
+	return prs, truncated, nil
+}
+
+// absorbDiscovery records the discovered PRs, adds runs for new ones and
+// archives the runs of closed ones.
+func (m *Model) absorbDiscovery(msg discoverResultMsg) tea.Cmd {
+	m.discovered = make(map[string]githuburl.Parsed, len(msg.Open))
+	for _, pr := range msg.Open {
+		m.discovered[pr.Key()] = pr
+	}
+
+	var (
+		cmds  []tea.Cmd
+		added []string
+	)
+	for _, outcome := range msg.Added {
+		if outcome.Err != nil {
+			// Forget it so the next round fetches it again.
+			delete(m.discovered, outcome.Source.Key())
+			msg.Err = errors.Join(msg.Err, fmt.Errorf("%s: %w", outcome.Label, outcome.Err))
+			continue
+		}
+		if len(outcome.Runs) == 0 {
+			// No workflow has started yet; look again next round.
+			delete(m.discovered, outcome.Source.Key())
+			continue
+		}
+		cmds = append(cmds, m.absorbFetch(fetchResultMsg{Runs: outcome.Runs, Source: outcome.Source}))
+		added = append(added, outcome.Label)
+	}
+
+	archived := 0
+	for _, pr := range msg.Closed {
+		archived += m.tracker.ArchiveSource(pr.Key())
+	}
+	if archived > 0 {
+		m.ensureSelectionBounds()
+		persistence.SaveTracker(m.tracker)
+	}
+
+	var notes []string
+	if len(added) > 0 {
+		sort.Strings(added)
+		notes = append(notes, fmt.Sprintf("Discovered %s", strings.Join(added, ", ")))
+	}
+	if archived > 0 {
+		notes = append(notes, fmt.Sprintf("archived %d run(s) of closed PRs", archived))
+	}
+	if msg.Truncated {
+		notes = append(notes, "PR search truncated at the page limit")
+	}
+	switch {
+	case msg.Err != nil:
+		m.setStatus(fmt.Sprintf("PR discovery: %v", msg.Err), statusError)
+	case len(notes) > 0:
+		m.setStatus(strings.Join(notes, "; "), statusSuccess)
+	}
+
+	cmds = append(cmds, m.scheduleDiscover(msg.Err))
+	return tea.Batch(cmds...)
+}
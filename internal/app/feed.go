@@ -0,0 +1,80 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+)
+
+// feedFilter turns the Actions page search query into a run filter, e.g.
+// `workflow:"Deploy prod" branch:main is:failure`. Unknown terms are ignored.
+func feedFilter(query string) githubclient.RunFilter {
+	var filter githubclient.RunFilter
+	for _, term := range splitQuery(query) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(key) {
+		case "workflow":
+			filter.Workflow = value
+		case "branch":
+			filter.Branch = value
+		case "actor":
+			filter.Actor = value
+		case "event":
+			filter.Event = value
+		case "is":
+			filter.Status = value
+		}
+	}
+	return filter
+}
+
+// splitQuery splits on whitespace outside double quotes.
+func splitQuery(query string) []string {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms
+}
+
+// initialFeedRuns picks what to show when a feed is first added: the runs
+// still going plus the newest one, rather than a page of finished history.
+// Later polls add every run newer than these.
+func initialFeedRuns(runs []githubclient.WorkflowRun) []githubclient.WorkflowRun {
+	var picked []githubclient.WorkflowRun
+	for i, run := range runs {
+		if i == 0 || !run.Status.Completed() {
+			picked = append(picked, run)
+		}
+	}
+	return picked
+}
+
+// isFeed reports whether a source lists runs across commits: a repository's
+// Actions page or a single workflow's runs.
+func isFeed(source githuburl.Parsed) bool {
+	return source.Kind == githuburl.KindActions || source.Kind == githuburl.KindWorkflow
+}
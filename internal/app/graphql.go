@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+)
+
+// batchRefresher is implemented by clients that can tell, for many runs and
+// PR sources at once, which ones changed. refreshCmd hands such a client a
+// single planning job per host; the jobs it plans are fetched like any other.
+type batchRefresher interface {
+	planRefresh(ctx context.Context, timeout time.Duration, batch refreshBatch) ([]refreshJob, error)
+}
+
+type refreshBatch struct {
+	inputs []refreshInput
+	prs    []githuburl.Parsed
+}
+
+func batchRefreshJob(batcher batchRefresher, batch refreshBatch) refreshJob {
+	return refreshJob{
+		label:    fmt.Sprintf("batch of %d run(s), %d PR(s)", len(batch.inputs), len(batch.prs)),
+		planSize: len(batch.inputs) + len(batch.prs),
+		plan: func(ctx context.Context, timeout time.Duration) ([]refreshJob, error) {
+			return batcher.planRefresh(ctx, timeout, batch)
+		},
+	}
+}
+
+// graphQLBackoff is how long a host whose GraphQL lookups failed (e.g. an
+// older GHES without the API) is refreshed over REST only.
+const graphQLBackoff = 10 * time.Minute
+
+// graphQLAPI is a githubAPI that can also answer the GraphQL lookups.
+type graphQLAPI interface {
+	githubAPI
+	RunStates(ctx context.Context, nodeIDs []string) (map[string]githubclient.RunState, error)
+	PullRequestHeads(ctx context.Context, prs []githubclient.PullRequestRef) (map[githubclient.PullRequestRef]githubclient.PullRequestHead, error)
+}
+
+// graphQLClient is the githubAPI used with --graphql. Single lookups go over
+// REST as usual; refreshes first ask GraphQL which runs and PR heads changed,
+// in a handful of queries, and re-fetch only those over REST. If GraphQL is
+// unavailable the whole batch falls back to REST, and GraphQL is left alone
+// for graphQLBackoff.
+type graphQLClient struct {
+	graphQLAPI
+
+	mu          sync.Mutex
+	prHeads     map[githubclient.PullRequestRef]githubclient.PullRequestHead
+	failedUntil time.Time
+}
+
+func withGraphQL(client githubAPI) githubAPI {
+	api, ok := client.(graphQLAPI)
+	if !ok {
+		return client
+	}
+	return &graphQLClient{graphQLAPI: api, prHeads: make(map[githubclient.PullRequestRef]githubclient.PullRequestHead)}
+}
+
+// planRefresh returns one ordinary refresh job for every run and PR source in
+// batch that GraphQL reports as changed, or for all of them when GraphQL
+// can't answer. The first failure is returned so it shows up in the status
+// line; later batches skip GraphQL quietly until the backoff ends.
+func (g *graphQLClient) planRefresh(ctx context.Context, timeout time.Duration, batch refreshBatch) ([]refreshJob, error) {
+	staleRuns, stalePRs := batch.inputs, batch.prs
+	var (
+		heads map[githubclient.PullRequestRef]githubclient.PullRequestHead
+		err   error
+	)
+	if g.graphQLAvailable(time.Now()) {
+		staleRuns, err = g.changedRuns(ctx, timeout, batch.inputs)
+		if err == nil {
+			stalePRs, heads, err = g.changedPullRequests(ctx, timeout, batch.prs)
+		}
+		switch {
+		case errors.Is(err, githubclient.ErrRateLimited) || ctx.Err() != nil:
+			return nil, err
+		case err != nil:
+			g.mu.Lock()
+			g.failedUntil = time.Now().Add(graphQLBackoff)
+			g.mu.Unlock()
+			staleRuns, stalePRs = batch.inputs, batch.prs
+			err = fmt.Errorf("GraphQL unavailable, using REST for %s: %w", graphQLBackoff, err)
+		}
+	}
+
+	jobs := make([]refreshJob, 0, len(staleRuns)+len(stalePRs))
+	for _, input := range staleRuns {
+		jobs = append(jobs, runRefreshJob(input))
+	}
+	for _, source := range stalePRs {
+		jobs = append(jobs, g.pullRequestJob(source, heads))
+	}
+	return jobs, err
+}
+
+func (g *graphQLClient) graphQLAvailable(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !now.Before(g.failedUntil)
+}
+
+// pullRequestJob re-fetches a stale PR source and only then remembers the
+// head GraphQL reported, so a failed fetch is retried on the next poll.
+func (g *graphQLClient) pullRequestJob(source githuburl.Parsed, heads map[githubclient.PullRequestRef]githubclient.PullRequestHead) refreshJob {
+	job := sourceRefreshJob(g, source, 0)
+	fetch := job.fetch
+	job.fetch = func(ctx context.Context, timeout time.Duration) (refreshResultMsg, error) {
+		msg, err := fetch(ctx, timeout)
+		if err != nil {
+			return msg, err
+		}
+		if head, ok := heads[prRef(source)]; ok {
+			g.mu.Lock()
+			g.prHeads[prRef(source)] = head
+			g.mu.Unlock()
+		}
+		return msg, nil
+	}
+	return job
+}
+
+// changedRuns returns the runs whose GraphQL state differs from what the
+// tracker last saw, or every run if GraphQL can't answer.
+func (g *graphQLClient) changedRuns(ctx context.Context, timeout time.Duration, inputs []refreshInput) ([]refreshInput, error) {
+	var nodeIDs []string
+	for _, input := range inputs {
+		if input.Current.NodeID != "" {
+			nodeIDs = append(nodeIDs, input.Current.NodeID)
+		}
+	}
+	if len(nodeIDs) == 0 {
+		return inputs, nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	states, err := g.RunStates(reqCtx, nodeIDs)
+	if err != nil {
+		return inputs, err
+	}
+
+	var stale []refreshInput
+	for _, input := range inputs {
+		state, ok := states[input.Current.NodeID]
+		if !ok || state.Status != input.Current.Status || state.StatusDetail != input.Current.StatusDetail ||
+			!state.UpdatedAt.Equal(input.Current.LastUpdatedAt) {
+			stale = append(stale, input)
+		}
+	}
+	return stale, nil
+}
+
+// changedPullRequests returns the PR sources whose head commit or number of
+// check suites changed since their runs were last fetched, along with the
+// heads GraphQL reported. Without GraphQL every source is stale.
+func (g *graphQLClient) changedPullRequests(ctx context.Context, timeout time.Duration, prs []githuburl.Parsed) ([]githuburl.Parsed, map[githubclient.PullRequestRef]githubclient.PullRequestHead, error) {
+	if len(prs) == 0 {
+		return nil, nil, nil
+	}
+	refs := make([]githubclient.PullRequestRef, 0, len(prs))
+	for _, source := range prs {
+		refs = append(refs, prRef(source))
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	heads, err := g.PullRequestHeads(reqCtx, refs)
+	if err != nil {
+		return prs, nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var stale []githuburl.Parsed
+	for _, source := range prs {
+		head, ok := heads[prRef(source)]
+		if !ok || head != g.prHeads[prRef(source)] {
+			stale = append(stale, source)
+		}
+	}
+	return stale, heads, nil
+}
+
+func prRef(source githuburl.Parsed) githubclient.PullRequestRef {
+	return githubclient.PullRequestRef{Owner: source.Owner, Repo: source.Repo, Number: source.PRNumber}
+}
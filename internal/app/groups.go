@@ -0,0 +1,274 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+	"github.com/nateberkopec/ghwatch/internal/persistence"
+	"github.com/nateberkopec/ghwatch/internal/watch"
+)
+
+// listRow is one line of the runs table. In the grouped view a row with only
+// group set is the group's header; its runs have both fields set.
+type listRow struct {
+	run   *watch.TrackedRun
+	group *runGroup
+}
+
+func (r listRow) header() bool {
+	return r.run == nil && r.group != nil
+}
+
+// runGroup gathers the visible runs added from one PR, commit, branch or
+// feed, newest first.
+type runGroup struct {
+	key    string
+	source githuburl.Parsed
+	runs   []*watch.TrackedRun
+}
+
+// groupable reports whether runs from source are grouped. Single-run sources
+// would only add a header per run.
+func groupable(source githuburl.Parsed) bool {
+	switch source.Kind {
+	case githuburl.KindUnknown, githuburl.KindWorkflowRun, githuburl.KindCheckRun:
+		return false
+	default:
+		return true
+	}
+}
+
+// listRows returns the table rows in display order. Groups sit where their
+// newest run would be in the flat list, and only expanded groups list their
+// runs.
+func (m *Model) listRows() []listRow {
+	runs := m.tracker.VisibleRuns(m.showArchived)
+	if !m.grouped {
+		rows := make([]listRow, len(runs))
+		for i, run := range runs {
+			rows[i] = listRow{run: run}
+		}
+		return rows
+	}
+
+	var entries []listRow
+	groups := map[string]*runGroup{}
+	for _, run := range runs {
+		if !groupable(run.Source) {
+			entries = append(entries, listRow{run: run})
+			continue
+		}
+		key := run.Source.Key()
+		group, ok := groups[key]
+		if !ok {
+			group = &runGroup{key: key, source: run.Source}
+			groups[key] = group
+			entries = append(entries, listRow{group: group})
+		}
+		group.runs = append(group.runs, run)
+	}
+
+	rows := make([]listRow, 0, len(runs))
+	for _, entry := range entries {
+		rows = append(rows, entry)
+		if entry.group != nil && m.expanded[entry.group.key] {
+			for _, run := range entry.group.runs {
+				rows = append(rows, listRow{run: run, group: entry.group})
+			}
+		}
+	}
+	return rows
+}
+
+func (m *Model) selectedRow() (listRow, bool) {
+	rows := m.listRows()
+	if len(rows) == 0 {
+		return listRow{}, false
+	}
+	m.selectedIndex = min(max(m.selectedIndex, 0), len(rows)-1)
+	return rows[m.selectedIndex], true
+}
+
+// selectRun moves the selection to a run, expanding its group if needed.
+func (m *Model) selectRun(id int64) bool {
+	for attempt := 0; attempt < 2; attempt++ {
+		for i, row := range m.listRows() {
+			if row.run != nil && row.run.Run.ID == id {
+				m.selectedIndex = i
+				m.ensureSelectionBounds()
+				return true
+			}
+			if row.header() && attempt == 0 && groupHas(row.group, id) {
+				m.expanded[row.group.key] = true
+			}
+		}
+	}
+	return false
+}
+
+func groupHas(group *runGroup, id int64) bool {
+	for _, run := range group.runs {
+		if run.Run.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) toggleGrouped() {
+	m.grouped = !m.grouped
+	m.selectedIndex = 0
+	m.scrollOffset = 0
+	if m.grouped {
+		m.setStatus("Grouping runs by PR, commit or branch (space expands a group)", statusNeutral)
+	} else {
+		m.setStatus("Showing every run", statusNeutral)
+	}
+}
+
+// toggleGroup expands or collapses the selected group. On a run inside an
+// expanded group it collapses the group and selects its header.
+func (m *Model) toggleGroup() {
+	row, ok := m.selectedRow()
+	if !ok || row.group == nil {
+		return
+	}
+	key := row.group.key
+	m.expanded[key] = !m.expanded[key]
+	if !m.expanded[key] {
+		for i, other := range m.listRows() {
+			if other.header() && other.group.key == key {
+				m.selectedIndex = i
+				break
+			}
+		}
+	}
+	m.ensureSelectionBounds()
+}
+
+// archiveGroup archives (or, in the archive view, restores) every run of a
+// group.
+func (m *Model) archiveGroup(group *runGroup) tea.Cmd {
+	for _, run := range group.runs {
+		if m.showArchived {
+			m.tracker.Unarchive(run.Run.ID)
+		} else {
+			m.tracker.Archive(run.Run.ID)
+		}
+	}
+	persistence.SaveTracker(m.tracker)
+	label := fmt.Sprintf("%d run(s) of %s", len(group.runs), group.source.String())
+	if m.showArchived {
+		m.showArchived = false
+		m.setStatus(fmt.Sprintf("Restored %s", label), statusSuccess)
+		return m.refreshCmd(false, true)
+	}
+	m.ensureSelectionBounds()
+	m.setStatus(fmt.Sprintf("Archived %s", label), statusNeutral)
+	return nil
+}
+
+// url is the page of the group's PR, commit or branch.
+func (g *runGroup) url() string {
+	for _, run := range g.runs {
+		for _, target := range []string{run.Run.PRURL, run.Run.TargetURL} {
+			if target != "" {
+				return target
+			}
+		}
+	}
+	if g.source.RawURL != "" {
+		return g.source.RawURL
+	}
+	return g.runs[0].Run.HTMLURL
+}
+
+// current leaves out runs superseded by a newer PR head, unless that is all
+// of them.
+func (g *runGroup) current() []*watch.TrackedRun {
+	var runs []*watch.TrackedRun
+	for _, run := range g.runs {
+		if !run.Superseded {
+			runs = append(runs, run)
+		}
+	}
+	if len(runs) == 0 {
+		return g.runs
+	}
+	return runs
+}
+
+// status aggregates the group: failed if any run failed, successful only if
+// every run succeeded (or was skipped), pending while any run is unfinished.
+func (g *runGroup) status() githubclient.RunStatus {
+	var (
+		pending bool
+		other   githubclient.RunStatus
+	)
+	for _, run := range g.current() {
+		switch status := run.Run.Status; {
+		case status == githubclient.RunStatusFailed:
+			return githubclient.RunStatusFailed
+		case status == githubclient.RunStatusSuccess, status == githubclient.RunStatusSkipped,
+			status == githubclient.RunStatusNeutral:
+		case !status.Completed():
+			pending = true
+		case other == "":
+			other = status
+		}
+	}
+	switch {
+	case pending:
+		return githubclient.RunStatusPending
+	case other != "":
+		return other
+	default:
+		return githubclient.RunStatusSuccess
+	}
+}
+
+var summaryOrder = []githubclient.RunStatus{
+	githubclient.RunStatusFailed,
+	githubclient.RunStatusActionRequired,
+	githubclient.RunStatusWaiting,
+	githubclient.RunStatusPending,
+	githubclient.RunStatusCancelled,
+	githubclient.RunStatusSuccess,
+	githubclient.RunStatusSkipped,
+	githubclient.RunStatusNeutral,
+}
+
+// summary counts the group's current runs by status, e.g. "❌ 2 ✅ 9".
+func (g *runGroup) summary() string {
+	counts := map[githubclient.RunStatus]int{}
+	for _, run := range g.current() {
+		counts[run.Run.Status]++
+	}
+	var parts []string
+	for _, status := range summaryOrder {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", statusIcon(status), counts[status]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func groupRowData(group *runGroup, expanded bool) []string {
+	owner, repo := splitRepo(group.runs[0].Run.RepoFullName)
+	arrow := "▸"
+	if expanded {
+		arrow = "▾"
+	}
+	return []string{
+		statusStyle(group.status()).Render(statusIcon(group.status())),
+		repo,
+		owner,
+		group.source.Label(),
+		fmt.Sprintf("%s %d runs", arrow, len(group.runs)),
+		group.summary(),
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+	"github.com/nateberkopec/ghwatch/internal/gitrepo"
+)
+
+// watchCheckout adds the runs for the local checkout: its branch's open PR if
+// there is one (so later pushes are followed), otherwise its HEAD commit.
+// The checkout is read when the command runs, so H picks up branch switches.
+func (m *Model) watchCheckout() tea.Cmd {
+	dir := m.checkoutDir
+	if dir == "" {
+		dir = "."
+	}
+	m.pendingFetch = true
+	m.setStatus("Watching the current checkout …", statusNeutral)
+	clientFor := m.clientFor
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		parsed, err := checkoutSource(ctx, dir, clientFor)
+		if err != nil {
+			return fetchErrMsg{Err: err}
+		}
+		runs, err := fetchSourceRuns(ctx, clientFor(parsed.Host), parsed, 0)
+		if err != nil {
+			return fetchErrMsg{Err: err}
+		}
+		return fetchResultMsg{Runs: runs, Source: parsed}
+	}
+}
+
+// checkoutSource resolves the checkout in dir to a PR or commit source.
+func checkoutSource(ctx context.Context, dir string, clientFor func(host string) githubAPI) (githuburl.Parsed, error) {
+	repo, err := gitrepo.Find(dir)
+	if errors.Is(err, gitrepo.ErrNotFound) {
+		return githuburl.Parsed{}, fmt.Errorf("%s is not inside a git checkout", dir)
+	}
+	if err != nil {
+		return githuburl.Parsed{}, err
+	}
+	host, owner, name, err := repo.GitHubRepo()
+	if err != nil {
+		return githuburl.Parsed{}, err
+	}
+	branch, sha, err := repo.Head()
+	if err != nil {
+		return githuburl.Parsed{}, err
+	}
+	if sha == "" {
+		return githuburl.Parsed{}, fmt.Errorf("%s has no commits yet", branch)
+	}
+
+	// Going through Parse checks the host is github.com or a configured
+	// enterprise host.
+	commit, err := githuburl.Parse(fmt.Sprintf("https://%s/%s/%s/commit/%s", host, owner, name, sha))
+	if err != nil {
+		return githuburl.Parsed{}, err
+	}
+	if branch == "" {
+		return commit, nil
+	}
+	number, err := clientFor(commit.Host).OpenPullRequestForBranch(ctx, owner, name, branch)
+	if err != nil {
+		return githuburl.Parsed{}, err
+	}
+	if number == 0 {
+		return commit, nil
+	}
+	return githuburl.Parse(fmt.Sprintf("https://%s/%s/%s/pull/%d", host, owner, name, number))
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	ansiPattern      = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+	timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+)
+
+const logErrorMarker = "##[error]"
+
+// logViewState backs the full-height log viewer for a single job. Raw log text
+// is kept so the ANSI/timestamp toggles can re-render without refetching.
+type logViewState struct {
+	open    bool
+	jobID   int64
+	title   string
+	raw     string
+	loading bool
+	err     error
+
+	stripANSI       bool
+	stripTimestamps bool
+
+	lines    []string
+	matches  []int
+	matchIdx int
+	query    string
+	searchOn bool
+	search   textinput.Model
+	viewport viewport.Model
+}
+
+type logsResultMsg struct {
+	JobID int64
+	Logs  string
+	Err   error
+}
+
+func newLogViewState() logViewState {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search"
+	search.CharLimit = 128
+	return logViewState{stripANSI: true, search: search}
+}
+
+// openLogs starts downloading the log for the job selected in the detail pane.
+func (m *Model) openLogs() tea.Cmd {
+	run := m.selectedRun()
+	job := m.selectedJob()
+	if run == nil || job == nil {
+		m.setStatus("Open details (d) and pick a job to view its log", statusNeutral)
+		return nil
+	}
+	owner, repo := splitRepo(run.Run.RepoFullName)
+	if owner == "" {
+		return nil
+	}
+
+	logs := newLogViewState()
+	logs.open = true
+	logs.loading = true
+	logs.jobID = job.ID
+	logs.title = fmt.Sprintf("%s • %s", run.Run.Name, job.Name)
+	logs.viewport = viewport.New(max(1, m.width), m.logViewportHeight())
+	m.logs = logs
+
+	client := m.clientFor(run.Run.Host)
+	jobID := job.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		text, err := client.JobLogs(ctx, owner, repo, jobID)
+		return logsResultMsg{JobID: jobID, Logs: text, Err: err}
+	}
+}
+
+func (m *Model) absorbLogs(msg logsResultMsg) {
+	if !m.logs.open || msg.JobID != m.logs.jobID {
+		return
+	}
+	m.logs.loading = false
+	m.logs.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	m.logs.raw = msg.Logs
+	m.renderLogContent()
+	m.jumpToFirstError()
+}
+
+func (m *Model) closeLogs() {
+	m.logs = logViewState{}
+}
+
+func (m *Model) logViewportHeight() int {
+	// Header line plus an optional search line sit above the viewport.
+	return max(1, m.listArea.height+m.detailArea.height-2)
+}
+
+func (m *Model) resizeLogs() {
+	if !m.logs.open {
+		return
+	}
+	m.logs.viewport.Width = max(1, m.width)
+	m.logs.viewport.Height = m.logViewportHeight()
+}
+
+// renderLogContent rebuilds the visible lines from the raw log according to
+// the current strip toggles and search query.
+func (m *Model) renderLogContent() {
+	rawLines := strings.Split(strings.TrimRight(m.logs.raw, "\n"), "\n")
+	lines := make([]string, len(rawLines))
+	m.logs.matches = m.logs.matches[:0]
+	query := strings.ToLower(m.logs.query)
+	for i, line := range rawLines {
+		line = strings.TrimPrefix(line, "\ufeff")
+		line = strings.TrimRight(line, "\r")
+		if m.logs.stripANSI {
+			line = ansiPattern.ReplaceAllString(line, "")
+		}
+		if m.logs.stripTimestamps {
+			line = timestampPattern.ReplaceAllString(line, "")
+		}
+		if query != "" && strings.Contains(strings.ToLower(ansiPattern.ReplaceAllString(line, "")), query) {
+			m.logs.matches = append(m.logs.matches, i)
+		}
+		if strings.Contains(line, logErrorMarker) {
+			line = statusErrorStyle.Render(ansiPattern.ReplaceAllString(line, ""))
+		}
+		lines[i] = line
+	}
+	m.logs.lines = lines
+	m.logs.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (m *Model) jumpToFirstError() {
+	for i, line := range m.logs.lines {
+		if strings.Contains(line, logErrorMarker) {
+			// Keep a little context above the error visible.
+			m.logs.viewport.SetYOffset(max(0, i-3))
+			return
+		}
+	}
+	m.logs.viewport.GotoBottom()
+}
+
+func (m *Model) jumpToMatch(delta int) {
+	if len(m.logs.matches) == 0 {
+		if m.logs.query != "" {
+			m.setStatus(fmt.Sprintf("No matches for %q", m.logs.query), statusNeutral)
+		}
+		return
+	}
+	m.logs.matchIdx = (m.logs.matchIdx + delta + len(m.logs.matches)) % len(m.logs.matches)
+	m.logs.viewport.SetYOffset(m.logs.matches[m.logs.matchIdx])
+}
+
+func (m *Model) handleLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if m.logs.searchOn {
+		switch key {
+		case "enter":
+			m.logs.searchOn = false
+			m.logs.search.Blur()
+			m.logs.query = strings.TrimSpace(m.logs.search.Value())
+			m.logs.matchIdx = -1
+			m.renderLogContent()
+			m.jumpToMatch(1)
+			return m, nil
+		case "esc":
+			m.logs.searchOn = false
+			m.logs.search.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.logs.search, cmd = m.logs.search.Update(msg)
+		return m, cmd
+	}
+
+	switch key {
+	case "esc", "q", "l":
+		m.closeLogs()
+		return m, nil
+	case "/":
+		m.logs.searchOn = true
+		m.logs.search.SetValue(m.logs.query)
+		m.logs.search.CursorEnd()
+		return m, m.logs.search.Focus()
+	case "n":
+		m.jumpToMatch(1)
+		return m, nil
+	case "N":
+		m.jumpToMatch(-1)
+		return m, nil
+	case "e":
+		m.jumpToFirstError()
+		return m, nil
+	case "t":
+		m.logs.stripTimestamps = !m.logs.stripTimestamps
+		m.renderLogContent()
+		return m, nil
+	case "c":
+		m.logs.stripANSI = !m.logs.stripANSI
+		m.renderLogContent()
+		return m, nil
+	case "g", "home":
+		m.logs.viewport.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.logs.viewport.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.logs.viewport, cmd = m.logs.viewport.Update(msg)
+	return m, cmd
+}
+
+func renderLogView(m *Model) string {
+	header := fmt.Sprintf("Log • %s", m.logs.title)
+	var flags []string
+	if m.logs.stripTimestamps {
+		flags = append(flags, "no timestamps")
+	}
+	if !m.logs.stripANSI {
+		flags = append(flags, "raw ANSI")
+	}
+	if m.logs.query != "" {
+		flags = append(flags, fmt.Sprintf("%q %d/%d", m.logs.query, m.logs.matchIdx+1, len(m.logs.matches)))
+	}
+	if len(flags) > 0 {
+		header = fmt.Sprintf("%s • %s", header, strings.Join(flags, " • "))
+	}
+
+	height := m.listArea.height + m.detailArea.height
+	lines := []string{headerStyle.Render(pad(truncate(header, m.width), m.width))}
+	switch {
+	case m.logs.err != nil:
+		lines = append(lines, statusErrorStyle.Render(m.logs.err.Error()))
+	case m.logs.loading:
+		lines = append(lines, helpStyle.Render("Downloading log…"))
+	default:
+		lines = append(lines, m.logs.viewport.View())
+	}
+	if m.logs.searchOn {
+		lines = append(lines, m.logs.search.View())
+	}
+
+	out := strings.Split(strings.Join(lines, "\n"), "\n")
+	if len(out) > height {
+		out = out[:height]
+	}
+	for len(out) < height {
+		out = append(out, strings.Repeat(" ", max(0, m.width)))
+	}
+	return strings.Join(out, "\n")
+}
@@ -25,6 +25,20 @@ type githubAPI interface {
 	WorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (githubclient.WorkflowRun, error)
 	RunsByPullRequest(ctx context.Context, owner, repo string, number int) ([]githubclient.WorkflowRun, error)
 	RunsByCommit(ctx context.Context, owner, repo, sha string) ([]githubclient.WorkflowRun, error)
+	RunsByBranch(ctx context.Context, owner, repo, branch string) ([]githubclient.WorkflowRun, error)
+	OpenPullRequestForBranch(ctx context.Context, owner, repo, branch string) (int, error)
+	SearchPullRequests(ctx context.Context, query string) ([]githubclient.PullRequestRef, bool, error)
+	PullRequestOpen(ctx context.Context, owner, repo string, number int) (bool, error)
+	RecentRuns(ctx context.Context, owner, repo string, filter githubclient.RunFilter) ([]githubclient.WorkflowRun, error)
+	RunsByWorkflow(ctx context.Context, owner, repo, workflow string) ([]githubclient.WorkflowRun, error)
+	JobsForRun(ctx context.Context, owner, repo string, runID int64) ([]githubclient.Job, error)
+	JobByID(ctx context.Context, owner, repo string, jobID int64) (githubclient.Job, error)
+	JobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error)
+	AnnotationsForCheckSuite(ctx context.Context, owner, repo string, suiteID int64) ([]githubclient.Annotation, error)
+	RerunRun(ctx context.Context, owner, repo string, runID int64) error
+	RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error
+	CancelRun(ctx context.Context, owner, repo string, runID int64) error
+	RateLimit() githubclient.RateLimit
 }
 
 type focusArea int
@@ -55,20 +69,66 @@ type area struct {
 
 // Config wires external dependencies for the app.
 type Config struct {
-	Client       githubAPI
+	Client githubAPI
+	// HostClients maps GitHub Enterprise Server hostnames to their clients.
+	// Runs from any other host use Client.
+	HostClients  map[string]*githubclient.Client
 	PollInterval time.Duration
 	BellEnabled  bool
+	// GraphQL batches refreshes through GitHub's GraphQL API, re-fetching over
+	// REST only the runs and PRs that changed.
+	GraphQL bool
+	// AllowWrite enables the rerun/cancel key bindings. The clients must also
+	// have writes enabled.
+	AllowWrite bool
+	// Concurrency bounds how many refresh requests run at once, and
+	// RequestTimeout bounds each of them.
+	Concurrency    int
+	RequestTimeout time.Duration
+	// CheckoutDir is the git checkout that H (and WatchHere at startup) adds
+	// runs for; empty means the working directory.
+	CheckoutDir string
+	WatchHere   bool
+	// DiscoverPRs periodically searches for open PRs the user authored or
+	// was asked to review, watching new ones and archiving closed ones.
+	DiscoverPRs      bool
+	DiscoverInterval time.Duration
+	// ArchiveSuperseded archives PR runs as soon as a new head commit
+	// supersedes them, instead of only marking them.
+	ArchiveSuperseded bool
+	// GroupBySource starts in the grouped view, with one collapsible row per
+	// PR, commit, branch or feed.
+	GroupBySource bool
 }
 
 // Model implements the Bubble Tea program.
 type Model struct {
 	client       githubAPI
+	hostClients  map[string]githubAPI
 	tracker      *watch.Tracker
 	pollInterval time.Duration
+	schedule     pollSchedule
+
+	concurrency    int
+	requestTimeout time.Duration
+
+	checkoutDir string
+	watchHere   bool
+
+	discoverPRs      bool
+	discoverInterval time.Duration
+	discovered       map[string]githuburl.Parsed // Open PRs found by discovery, by Key()
+
+	archiveSuperseded bool
+
+	grouped  bool
+	expanded map[string]bool // Expanded groups in the grouped view, by source key
 
 	focus        focusArea
 	showArchived bool
 	bellEnabled  bool
+	allowWrite   bool
+	confirm      *runAction
 
 	selectedIndex int
 	scrollOffset  int
@@ -81,9 +141,14 @@ type Model struct {
 	status       statusMessage
 	pendingFetch bool
 	refreshing   bool
+	pausedUntil  time.Time
+
+	listArea   area
+	inputArea  area
+	detailArea area
 
-	listArea  area
-	inputArea area
+	detail detailState
+	logs   logViewState
 
 	history      []string
 	historyIndex int
@@ -97,15 +162,40 @@ func New(cfg Config) *Model {
 		client = githubclient.New("")
 	}
 
+	hostClients := make(map[string]githubAPI, len(cfg.HostClients))
+	for host, hostClient := range cfg.HostClients {
+		hostClients[host] = hostClient
+	}
+	if cfg.GraphQL {
+		client = withGraphQL(client)
+		for host, hostClient := range hostClients {
+			hostClients[host] = withGraphQL(hostClient)
+		}
+	}
+
 	pollInterval := cfg.PollInterval
 	if pollInterval <= 0 {
 		pollInterval = 10 * time.Second
 	}
 
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+
+	discoverInterval := cfg.DiscoverInterval
+	if discoverInterval <= 0 {
+		discoverInterval = defaultDiscoverInterval
+	}
+
 	ti := textinput.New()
 	ti.Placeholder = "Paste a GitHub workflow/run URL"
 	ti.Prompt = ""
-	ti.CharLimit = 256
+	ti.CharLimit = 8192 // Room for a pasted list of links
 	ti.Blur()
 
 	sp := spinner.New(spinner.WithSpinner(spinner.Ellipsis))
@@ -120,21 +210,43 @@ func New(cfg Config) *Model {
 	}
 
 	return &Model{
-		client:       client,
-		tracker:      tracker,
-		pollInterval: pollInterval,
-		bellEnabled:  cfg.BellEnabled,
-		input:        ti,
-		spin:         sp,
-		history:      history,
-		historyIndex: len(history),
+		client:         client,
+		hostClients:    hostClients,
+		tracker:        tracker,
+		pollInterval:   pollInterval,
+		schedule:       newPollSchedule(),
+		concurrency:    concurrency,
+		requestTimeout: requestTimeout,
+		checkoutDir:    cfg.CheckoutDir,
+		watchHere:      cfg.WatchHere,
+		bellEnabled:    cfg.BellEnabled,
+		allowWrite:     cfg.AllowWrite,
+		input:          ti,
+		spin:           sp,
+		history:        history,
+		historyIndex:   len(history),
+
+		discoverPRs:      cfg.DiscoverPRs,
+		discoverInterval: discoverInterval,
+
+		archiveSuperseded: cfg.ArchiveSuperseded,
+
+		grouped:  cfg.GroupBySource,
+		expanded: map[string]bool{},
 	}
 }
 
 // Init satisfies the tea.Model interface.
 func (m *Model) Init() tea.Cmd {
 	spinCmd := func() tea.Msg { return m.spin.Tick() }
-	return tea.Batch(textinput.Blink, m.scheduleRefresh(), spinCmd)
+	cmds := []tea.Cmd{textinput.Blink, m.scheduleRefresh(), spinCmd}
+	if m.watchHere {
+		cmds = append(cmds, m.watchCheckout())
+	}
+	if m.discoverPRs {
+		cmds = append(cmds, m.discoverCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update drives the Bubble Tea state machine.
@@ -146,43 +258,94 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.configureLayout()
+		m.resizeLogs()
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spin, cmd = m.spin.Update(msg)
 		return m, cmd
 	case tea.MouseMsg:
-		return m.handleMouse(msg)
+		model, cmd := m.handleMouse(msg)
+		return model, tea.Batch(cmd, m.syncDetail())
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		model, cmd := m.handleKey(msg)
+		return model, tea.Batch(cmd, m.syncDetail())
+	case jobsResultMsg:
+		m.absorbJobs(msg)
+	case logsResultMsg:
+		m.absorbLogs(msg)
+	case annotationsResultMsg:
+		m.absorbAnnotations(msg)
+	case actionResultMsg:
+		return m, m.absorbActionResult(msg)
+	case clipboardResultMsg:
+		if msg.Err != nil {
+			m.setStatus(fmt.Sprintf("Copy failed: %v", msg.Err), statusError)
+		} else {
+			m.setStatus(fmt.Sprintf("Copied %s", msg.Text), statusSuccess)
+		}
 	case fetchResultMsg:
 		m.pendingFetch = false
-		cmd := m.absorbRuns(msg.Runs, msg.Source)
-		return m, cmd
+		return m, m.absorbFetch(msg)
+	case fetchManyResultMsg:
+		m.pendingFetch = false
+		return m, m.absorbFetchMany(msg)
+	case discoverTickMsg:
+		return m, m.discoverCmd()
+	case discoverResultMsg:
+		return m, m.absorbDiscovery(msg)
 	case fetchErrMsg:
 		m.pendingFetch = false
+		m.noteRateLimit(msg.Err)
 		m.setStatus(msg.Err.Error(), statusError)
 	case openErrMsg:
 		m.setStatus(msg.Err.Error(), statusError)
 	case refreshTickMsg:
 		cmds := []tea.Cmd{m.scheduleRefresh()}
-		if refreshCmd := m.refreshCmd(true); refreshCmd != nil {
+		if m.rateLimited() {
+			return m, tea.Batch(cmds...)
+		}
+		if refreshCmd := m.refreshCmd(true, false); refreshCmd != nil {
 			cmds = append(cmds, refreshCmd)
 		}
+		if detailCmd := m.refreshDetailCmd(false); detailCmd != nil {
+			cmds = append(cmds, detailCmd)
+		}
 		return m, tea.Batch(cmds...)
 	case refreshResultMsg:
-		m.refreshing = false
+		if msg.done && msg.auto {
+			// Only the scheduled batch holds the guard; a manual refresh
+			// finishing must not let the next tick overlap it.
+			m.refreshing = false
+		}
+		if msg.RateLimitedUntil.After(m.pausedUntil) {
+			m.pausedUntil = msg.RateLimitedUntil
+		}
 		if msg.Err != nil {
 			m.setStatus(msg.Err.Error(), statusError)
 		}
-		// Absorb individual run refreshes (preserve existing sources)
-		cmd := m.absorbRuns(msg.Runs, githuburl.Parsed{})
-		// Absorb PR runs with their respective sources (for new runs)
-		for prSource, runs := range msg.PRRuns {
-			prCmd := m.absorbRuns(runs, prSource)
-			if prCmd != nil {
-				cmd = tea.Batch(cmd, prCmd)
+		// Absorb individual run refreshes (preserve existing sources). Empty
+		// lists are skipped: their "no runs" notice would hide msg.Err.
+		var cmd tea.Cmd
+		if len(msg.Runs) > 0 {
+			cmd = m.absorbRuns(msg.Runs, githuburl.Parsed{})
+		}
+		// Absorb PR/branch runs with their respective sources (for new runs)
+		for source, runs := range msg.SourceRuns {
+			if len(runs) == 0 {
+				continue
+			}
+			sourceCmd := m.absorbRuns(runs, source)
+			if sourceCmd != nil {
+				cmd = tea.Batch(cmd, sourceCmd)
 			}
 		}
+		m.rescheduleRuns(msg.Runs)
+		for _, runs := range msg.SourceRuns {
+			m.rescheduleRuns(runs)
+		}
+		if msg.more != nil {
+			cmd = tea.Batch(cmd, waitForRefresh(msg.more))
+		}
 		return m, cmd
 	}
 
@@ -209,7 +372,7 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			index := m.scrollOffset + row - 1
-			if index >= 0 && index < len(m.tracker.VisibleRuns(m.showArchived)) {
+			if index >= 0 && index < len(m.listRows()) {
 				m.selectedIndex = index
 				m.setFocus(focusRuns)
 				m.ensureSelectionBounds()
@@ -228,6 +391,16 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	if m.logs.open && key != "ctrl+c" {
+		return m.handleLogKey(msg)
+	}
+	if m.confirm != nil && key != "ctrl+c" {
+		return m, m.resolveConfirm(key)
+	}
+	if msg.Paste {
+		return m.handlePaste(msg)
+	}
+
 	switch key {
 	case "ctrl+c", "ctrl+d", "q":
 		persistence.SaveTracker(m.tracker)
@@ -271,13 +444,38 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.selectedIndex = 0
 		m.scrollOffset = 0
 	case "G", "end":
-		m.selectedIndex = len(m.tracker.VisibleRuns(m.showArchived)) - 1
+		m.selectedIndex = len(m.listRows()) - 1
 		if m.selectedIndex < 0 {
 			m.selectedIndex = 0
 		}
 	case "o", "enter":
 		return m, m.openSelected()
+	case "v":
+		m.toggleGrouped()
+	case " ":
+		m.toggleGroup()
+	case "d":
+		return m, m.toggleDetail()
+	case "n":
+		return m, m.toggleAnnotations()
+	case "]":
+		m.moveDetail(1)
+	case "[":
+		m.moveDetail(-1)
+	case "y":
+		return m, m.copyAnnotationLocation()
+	case "R":
+		m.requestAction("rerun")
+	case "F":
+		m.requestAction("rerun-failed")
+	case "X":
+		m.requestAction("cancel")
+	case "l":
+		return m, m.openLogs()
 	case "a":
+		if row, ok := m.selectedRow(); ok && row.header() {
+			return m, m.archiveGroup(row.group)
+		}
 		if m.showArchived {
 			if cmd := m.unarchiveSelected(); cmd != nil {
 				return m, cmd
@@ -301,6 +499,13 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.setStatus("Bell muted", statusNeutral)
 		}
+	case "H":
+		return m, m.watchCheckout()
+	case "r":
+		if cmd := m.refreshCmd(false, true); cmd != nil {
+			m.setStatus("Refreshing all runs…", statusNeutral)
+			return m, tea.Batch(cmd, m.refreshDetailCmd(true))
+		}
 	case "B":
 		// Debug: test notification
 		m.setStatus("DEBUG: Notification triggered!", statusSuccess)
@@ -314,27 +519,50 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) submitURL() (tea.Model, tea.Cmd) {
 	value := strings.TrimSpace(m.input.Value())
 	if value == "" {
-		m.setStatus("Enter a GitHub Actions, PR, or commit URL", statusNeutral)
+		m.setStatus("Enter a GitHub Actions, PR, commit, or branch URL", statusNeutral)
 		return m, nil
 	}
 
+	if fields := strings.Fields(value); len(fields) > 1 {
+		m.rememberInput(value)
+		m.input.SetValue("")
+		return m, m.submitMany(fields)
+	}
+
 	parsed, err := githuburl.Parse(value)
 	if err != nil {
 		m.setStatus(err.Error(), statusError)
 		return m, nil
 	}
 
-	// Add to history (avoid duplicates of the most recent command)
+	m.rememberInput(value)
+	m.input.SetValue("")
+	m.pendingFetch = true
+	m.setStatus(fmt.Sprintf("Watching %s …", parsed.String()), statusNeutral)
+	return m, fetchRunsCmd(m.clientFor(parsed.Host), parsed)
+}
+
+// rememberInput adds a submitted value to the input history, skipping
+// repeats of the most recent entry.
+func (m *Model) rememberInput(value string) {
 	if len(m.history) == 0 || m.history[len(m.history)-1] != value {
 		m.history = append(m.history, value)
 	}
 	m.historyIndex = len(m.history)
 	m.tempInput = ""
+}
 
-	m.input.SetValue("")
-	m.pendingFetch = true
-	m.setStatus(fmt.Sprintf("Watching %s …", parsed.String()), statusNeutral)
-	return m, fetchRunsCmd(m.client, parsed)
+// absorbFetch adds the runs fetched for a newly submitted source.
+func (m *Model) absorbFetch(msg fetchResultMsg) tea.Cmd {
+	runs := msg.Runs
+	if isFeed(msg.Source) {
+		runs = initialFeedRuns(runs)
+	}
+	cmd := m.absorbRuns(runs, msg.Source)
+	if msg.Source.JobID != 0 && len(runs) > 0 {
+		cmd = tea.Batch(cmd, m.focusJob(runs[0].ID, msg.Source.JobID))
+	}
+	return cmd
 }
 
 func (m *Model) archiveSelected() {
@@ -357,40 +585,35 @@ func (m *Model) unarchiveSelected() tea.Cmd {
 		m.showArchived = false
 		persistence.SaveTracker(m.tracker)
 		m.setStatus(fmt.Sprintf("Restored %s", runLabel(run.Run)), statusSuccess)
-		return m.refreshCmd(false)
+		return m.refreshCmd(false, true)
 	}
 	return nil
 }
 
 func (m *Model) openSelected() tea.Cmd {
-	run := m.selectedRun()
-	if run == nil {
+	row, ok := m.selectedRow()
+	if !ok {
 		return nil
 	}
-	target := run.Run.PRURL
-	if target == "" {
-		target = run.Run.HTMLURL
+	var target string
+	if row.header() {
+		target = row.group.url()
+	} else if target = row.run.Run.PRURL; target == "" {
+		target = row.run.Run.HTMLURL
 	}
 	m.setStatus(fmt.Sprintf("Opening %s", target), statusNeutral)
 	return openURLCmd(target)
 }
 
+// selectedRun returns the selected run, or nil when nothing or a group header
+// is selected.
 func (m *Model) selectedRun() *watch.TrackedRun {
-	runs := m.tracker.VisibleRuns(m.showArchived)
-	if len(runs) == 0 {
-		return nil
-	}
-	if m.selectedIndex < 0 {
-		m.selectedIndex = 0
-	}
-	if m.selectedIndex >= len(runs) {
-		m.selectedIndex = len(runs) - 1
-	}
-	return runs[m.selectedIndex]
+	row, _ := m.selectedRow()
+	return row.run
 }
 
 func (m *Model) moveSelection(delta int) {
-	runs := m.tracker.VisibleRuns(m.showArchived)
+	runs := m.listRows()
 	if len(runs) == 0 {
 		m.selectedIndex = 0
 		m.scrollOffset = 0
@@ -420,7 +643,7 @@ func (m *Model) ensureSelectionBounds() {
 	if m.scrollOffset < 0 {
 		m.scrollOffset = 0
 	}
-	maxScroll := len(m.tracker.VisibleRuns(m.showArchived)) - dataRows
+	maxScroll := len(m.listRows()) - dataRows
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -502,11 +725,28 @@ func (m *Model) absorbRuns(runs []githubclient.WorkflowRun, source githuburl.Par
 		m.setStatus(label, statusNeutral)
 		return nil
 	}
+	if source.Kind == githuburl.KindPullRequest {
+		// RunsByPullRequest lists the runs of the PR's current head.
+		source.SHA = runs[0].HeadSHA
+	}
 	shouldRing := false
 	added := false
+	truncated := false
 	var changedRun *githubclient.WorkflowRun
+	upsert := m.tracker.Upsert
+	if isFeed(source) {
+		// Feeds list a page of mostly old runs; only take ones newer than
+		// what was already seen, and leave archived runs archived.
+		since := m.tracker.LatestID(source.Key())
+		upsert = func(run githubclient.WorkflowRun, source githuburl.Parsed) (bool, bool) {
+			return m.tracker.UpsertNew(run, source, since)
+		}
+	}
 	for _, run := range runs {
-		isNew, changed := m.tracker.Upsert(run, source)
+		if run.Truncated {
+			truncated = true
+		}
+		isNew, changed := upsert(run, source)
 		if isNew {
 			added = true
 		}
@@ -515,22 +755,32 @@ func (m *Model) absorbRuns(runs []githubclient.WorkflowRun, source githuburl.Par
 			changedRun = &run
 		}
 	}
+	superseded := m.tracker.MarkSuperseded(source)
+	if m.archiveSuperseded {
+		for _, id := range superseded {
+			m.tracker.Archive(id)
+		}
+	}
+	if added || len(superseded) > 0 {
+		m.ensureSelectionBounds()
+		persistence.SaveTracker(m.tracker)
+	}
 	if added {
 		m.selectedIndex = 0
 		m.scrollOffset = 0
-		persistence.SaveTracker(m.tracker)
-		m.setStatus(fmt.Sprintf("Watching %d run(s)", len(runs)), statusSuccess)
+		// The truncation notice is only given here: on every later poll it
+		// would hide the refresh errors reported alongside.
+		if truncated {
+			m.setStatus(fmt.Sprintf("Watching %d run(s) — list truncated at the page limit", len(runs)), statusNeutral)
+		} else {
+			m.setStatus(fmt.Sprintf("Watching %d run(s)", len(runs)), statusSuccess)
+		}
+	} else if len(superseded) > 0 && m.archiveSuperseded {
+		m.setStatus(fmt.Sprintf("Archived %d run(s) superseded by a new head of %s", len(superseded), source.String()), statusNeutral)
 	}
 	if shouldRing && m.bellEnabled && changedRun != nil {
-		statusText := "completed"
-		switch changedRun.Status {
-		case githubclient.RunStatusSuccess:
-			statusText = "succeeded"
-		case githubclient.RunStatusFailed:
-			statusText = "failed"
-		}
 		title := fmt.Sprintf("%s", changedRun.RepoFullName)
-		message := fmt.Sprintf("%s %s", changedRun.WorkflowName, statusText)
+		message := fmt.Sprintf("%s %s", changedRun.WorkflowName, statusVerb(changedRun.Status))
 		notify(title, message)
 	}
 	return nil
@@ -549,6 +799,11 @@ func (m *Model) configureLayout() {
 	if listHeight < 5 {
 		listHeight = 5
 	}
+	detailHeight := 0
+	if m.detail.open {
+		detailHeight = max(5, listHeight/2)
+		listHeight = max(3, listHeight-detailHeight)
+	}
 	m.inputArea = area{
 		top:    0,
 		height: inputHeight,
@@ -557,6 +812,10 @@ func (m *Model) configureLayout() {
 		top:    inputHeight + helpHeight,
 		height: listHeight,
 	}
+	m.detailArea = area{
+		top:    m.listArea.top + listHeight,
+		height: detailHeight,
+	}
 	m.input.Width = max(10, m.width-5) // Account for border + padding + margins
 }
 
@@ -564,12 +823,21 @@ func (m *Model) scheduleRefresh() tea.Cmd {
 	if m.pollInterval <= 0 {
 		return nil
 	}
-	return tea.Tick(m.pollInterval, func(time.Time) tea.Msg {
+	return tea.Tick(m.schedulerTick(), func(time.Time) tea.Msg {
 		return refreshTickMsg{}
 	})
 }
 
-func (m *Model) refreshCmd(auto bool) tea.Cmd {
+// refreshCmd re-fetches watched runs that are due according to the poll
+// schedule, or every run when force is set. Runs that already reached a final
+// state are skipped unless forced or just rerun or cancelled; they still get
+// updated whenever their PR or branch source is re-fetched, which is also how
+// new runs are discovered.
+func (m *Model) refreshCmd(auto, force bool) tea.Cmd {
+	if auto && m.refreshing {
+		// The previous batch is still running; its runs aren't due yet anyway.
+		return nil
+	}
 	active := m.tracker.VisibleRuns(false)
 	if len(active) == 0 {
 		if auto {
@@ -577,29 +845,39 @@ func (m *Model) refreshCmd(auto bool) tea.Cmd {
 		}
 		return nil
 	}
+	now := time.Now()
 	inputs := make([]refreshInput, 0, len(active))
 
-	// Collect unique PR sources to re-fetch for new workflow runs
-	prSources := make(map[string]githuburl.Parsed)
+	// Collect unique PR/branch sources to re-fetch for new workflow runs
+	sources := make(map[string]githuburl.Parsed)
 	for _, run := range active {
 		owner, repo := splitRepo(run.Run.RepoFullName)
 		if owner == "" {
 			continue
 		}
-		inputs = append(inputs, refreshInput{
-			RunID: run.Run.ID, Owner: owner, Repo: repo,
-		})
+		if force || (m.polled(run) && m.schedule.runDue(run.Run.ID, now)) {
+			inputs = append(inputs, refreshInput{
+				RunID: run.Run.ID, Owner: owner, Repo: repo,
+				Client: m.clientFor(run.Run.Host), Current: run.Run,
+			})
+			// Push the next poll out now so the run isn't fetched again while
+			// this request is in flight; the result reschedules it.
+			m.scheduleRun(run, now)
+		}
 
-		// Track PR sources to check for new runs on those PRs
-		if run.Source.Kind == githuburl.KindPullRequest {
-			key := fmt.Sprintf("%s/%s/%d", run.Source.Owner, run.Source.Repo, run.Source.PRNumber)
-			if _, exists := prSources[key]; !exists {
-				prSources[key] = run.Source
+		// Track sources that can gain runs (new workflows, new pushes)
+		if followsSource(run.Source) {
+			key := run.Source.Key()
+			if _, exists := sources[key]; !exists && (force || m.schedule.sourceDue(key, now)) {
+				sources[key] = run.Source
 			}
 		}
 	}
+	for key := range sources {
+		m.schedule.sources[key] = now.Add(m.pollInterval)
+	}
 
-	if len(inputs) == 0 {
+	if len(inputs) == 0 && len(sources) == 0 {
 		if auto {
 			m.refreshing = false
 		}
@@ -608,42 +886,66 @@ func (m *Model) refreshCmd(auto bool) tea.Cmd {
 	if auto {
 		m.refreshing = true
 	}
-	client := m.client
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		refreshed := make([]githubclient.WorkflowRun, 0, len(inputs))
-		prRuns := make(map[githuburl.Parsed][]githubclient.WorkflowRun)
-		var errs []string
-
-		// Refresh individual workflow runs by ID
-		for _, target := range inputs {
-			run, err := client.WorkflowRunByID(ctx, target.Owner, target.Repo, target.RunID)
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("%s/%s #%d: %v", target.Owner, target.Repo, target.RunID, err))
-				continue
-			}
-			refreshed = append(refreshed, run)
+	jobs := make([]refreshJob, 0, len(inputs)+len(sources))
+	batches := make(map[batchRefresher]*refreshBatch)
+	batchFor := func(client githubAPI) *refreshBatch {
+		batcher, ok := client.(batchRefresher)
+		if !ok {
+			return nil
 		}
-
-		// Re-fetch PR runs to catch new workflow runs on watched PRs
-		for _, prSource := range prSources {
-			runs, err := client.RunsByPullRequest(ctx, prSource.Owner, prSource.Repo, prSource.PRNumber)
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("PR %s/%s #%d: %v", prSource.Owner, prSource.Repo, prSource.PRNumber, err))
+		if batches[batcher] == nil {
+			batches[batcher] = &refreshBatch{}
+		}
+		return batches[batcher]
+	}
+	for _, input := range inputs {
+		if batch := batchFor(input.Client); batch != nil {
+			batch.inputs = append(batch.inputs, input)
+			continue
+		}
+		jobs = append(jobs, runRefreshJob(input))
+	}
+	for _, source := range sources {
+		client := m.clientFor(source.Host)
+		if source.Kind == githuburl.KindPullRequest {
+			if batch := batchFor(client); batch != nil {
+				batch.prs = append(batch.prs, source)
 				continue
 			}
-			prRuns[prSource] = runs
 		}
-
-		var err error
-		if len(errs) > 0 {
-			err = errors.New(strings.Join(errs, "; "))
+		var since int64
+		if isFeed(source) {
+			since = m.tracker.LatestID(source.Key())
 		}
-		return refreshResultMsg{Runs: refreshed, PRRuns: prRuns, Err: err}
+		jobs = append(jobs, sourceRefreshJob(client, source, since))
+	}
+	for batcher, batch := range batches {
+		jobs = append(jobs, batchRefreshJob(batcher, *batch))
+	}
+	return m.startRefresh(jobs, auto)
+}
+
+// clientFor picks the API client for a web host; unknown or empty hosts (runs
+// saved before enterprise support) go to github.com.
+func (m *Model) clientFor(host string) githubAPI {
+	if client, ok := m.hostClients[host]; ok {
+		return client
+	}
+	return m.client
+}
+
+// noteRateLimit pauses auto-refresh when err says GitHub's quota is exhausted.
+func (m *Model) noteRateLimit(err error) {
+	var rateErr *githubclient.RateLimitError
+	if errors.As(err, &rateErr) && rateErr.RetryAt.After(m.pausedUntil) {
+		m.pausedUntil = rateErr.RetryAt
 	}
 }
 
+func (m *Model) rateLimited() bool {
+	return time.Now().Before(m.pausedUntil)
+}
+
 func (m *Model) setStatus(text string, kind statusKind) {
 	if text == "" {
 		m.status = statusMessage{}
@@ -672,9 +974,14 @@ func (a area) contains(y int) bool {
 type refreshTickMsg struct{}
 
 type refreshResultMsg struct {
-	Runs   []githubclient.WorkflowRun
-	PRRuns map[githuburl.Parsed][]githubclient.WorkflowRun // Runs fetched from PR sources
-	Err    error
+	Runs             []githubclient.WorkflowRun
+	SourceRuns       map[githuburl.Parsed][]githubclient.WorkflowRun // Runs fetched from PR/branch sources
+	Err              error
+	RateLimitedUntil time.Time // Zero unless GitHub asked us to back off
+
+	more <-chan refreshResultMsg // Set on partial results; more are coming
+	done bool                    // Set on the last message of a refresh batch
+	auto bool                    // Set on every message of a scheduled batch
 }
 
 type fetchResultMsg struct {
@@ -687,9 +994,11 @@ type fetchErrMsg struct {
 }
 
 type refreshInput struct {
-	RunID int64
-	Owner string
-	Repo  string
+	RunID   int64
+	Owner   string
+	Repo    string
+	Client  githubAPI
+	Current githubclient.WorkflowRun // Last known state, for batch change detection
 }
 
 type openErrMsg struct {
@@ -700,25 +1009,7 @@ func fetchRunsCmd(client githubAPI, parsed githuburl.Parsed) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		var (
-			runs []githubclient.WorkflowRun
-			err  error
-		)
-		switch parsed.Kind {
-		case githuburl.KindWorkflowRun:
-			run, runErr := client.WorkflowRunByID(ctx, parsed.Owner, parsed.Repo, parsed.RunID)
-			if runErr != nil {
-				err = runErr
-			} else {
-				runs = []githubclient.WorkflowRun{run}
-			}
-		case githuburl.KindPullRequest:
-			runs, err = client.RunsByPullRequest(ctx, parsed.Owner, parsed.Repo, parsed.PRNumber)
-		case githuburl.KindCommit:
-			runs, err = client.RunsByCommit(ctx, parsed.Owner, parsed.Repo, parsed.SHA)
-		default:
-			err = fmt.Errorf("unsupported GitHub URL")
-		}
+		runs, err := fetchSourceRuns(ctx, client, parsed, 0)
 		if err != nil {
 			return fetchErrMsg{Err: err}
 		}
@@ -726,6 +1017,55 @@ func fetchRunsCmd(client githubAPI, parsed githuburl.Parsed) tea.Cmd {
 	}
 }
 
+// fetchSourceRuns lists the runs a parsed URL refers to. since is the newest
+// run already tracked from an Actions feed, which is read back to it; it is
+// zero on a first fetch.
+func fetchSourceRuns(ctx context.Context, client githubAPI, parsed githuburl.Parsed, since int64) ([]githubclient.WorkflowRun, error) {
+	switch parsed.Kind {
+	case githuburl.KindWorkflowRun:
+		run, err := client.WorkflowRunByID(ctx, parsed.Owner, parsed.Repo, parsed.RunID)
+		if err != nil {
+			return nil, err
+		}
+		return []githubclient.WorkflowRun{run}, nil
+	case githuburl.KindCheckRun:
+		job, err := client.JobByID(ctx, parsed.Owner, parsed.Repo, parsed.JobID)
+		if err != nil {
+			return nil, err
+		}
+		run, err := client.WorkflowRunByID(ctx, parsed.Owner, parsed.Repo, job.RunID)
+		if err != nil {
+			return nil, err
+		}
+		return []githubclient.WorkflowRun{run}, nil
+	case githuburl.KindPullRequest:
+		return client.RunsByPullRequest(ctx, parsed.Owner, parsed.Repo, parsed.PRNumber)
+	case githuburl.KindCommit:
+		return client.RunsByCommit(ctx, parsed.Owner, parsed.Repo, parsed.SHA)
+	case githuburl.KindBranch:
+		return client.RunsByBranch(ctx, parsed.Owner, parsed.Repo, parsed.Branch)
+	case githuburl.KindActions:
+		filter := feedFilter(parsed.Query)
+		filter.Since = since
+		return client.RecentRuns(ctx, parsed.Owner, parsed.Repo, filter)
+	case githuburl.KindWorkflow:
+		return client.RunsByWorkflow(ctx, parsed.Owner, parsed.Repo, parsed.Workflow)
+	default:
+		return nil, fmt.Errorf("unsupported GitHub URL")
+	}
+}
+
+// followsSource reports whether re-fetching a source can turn up new runs:
+// PRs gain workflows and pushes, branches move to new commits, and feeds list
+// every new run in a repository or of a workflow.
+func followsSource(source githuburl.Parsed) bool {
+	switch source.Kind {
+	case githuburl.KindPullRequest, githuburl.KindBranch:
+		return true
+	}
+	return isFeed(source)
+}
+
 func openURLCmd(target string) tea.Cmd {
 	return func() tea.Msg {
 		name, args := openCommand(target)
@@ -759,6 +1099,30 @@ func splitRepo(full string) (string, string) {
 	return parts[0], parts[1]
 }
 
+// statusVerb phrases a status for notifications, e.g. "CI was cancelled".
+func statusVerb(status githubclient.RunStatus) string {
+	switch status {
+	case githubclient.RunStatusSuccess:
+		return "succeeded"
+	case githubclient.RunStatusFailed:
+		return "failed"
+	case githubclient.RunStatusCancelled:
+		return "was cancelled"
+	case githubclient.RunStatusSkipped:
+		return "was skipped"
+	case githubclient.RunStatusNeutral:
+		return "completed (neutral)"
+	case githubclient.RunStatusWaiting:
+		return "is waiting for approval"
+	case githubclient.RunStatusActionRequired:
+		return "requires action"
+	case githubclient.RunStatusPending:
+		return "is running"
+	default:
+		return "completed"
+	}
+}
+
 func runLabel(run githubclient.WorkflowRun) string {
 	if run.Target != "" {
 		return fmt.Sprintf("%s • %s", run.RepoFullName, run.Target)
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+)
+
+// fetchOutcome is the result for one of several references submitted at once.
+type fetchOutcome struct {
+	Label  string
+	Source githuburl.Parsed
+	Client githubAPI
+	Runs   []githubclient.WorkflowRun
+	Err    error
+}
+
+type fetchManyResultMsg struct {
+	Outcomes []fetchOutcome
+}
+
+// handlePaste takes bracketed pastes anywhere outside the log view. A paste
+// with several references (say, a list of PR links copied from chat) is
+// submitted right away; a single one goes into the input to be checked first.
+func (m *Model) handlePaste(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if fields := strings.Fields(string(msg.Runes)); len(fields) > 1 {
+		m.rememberInput(strings.Join(fields, " "))
+		return m, m.submitMany(fields)
+	}
+	m.setFocus(focusInput)
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submitMany parses every reference among fields and fetches them
+// concurrently. Only URLs and the owner/repo#123 and owner/repo@ref short
+// forms count: a stray number or #123 in surrounding chat text is skipped
+// rather than resolved against the default repository. References that fail
+// to parse are reported in the summary.
+func (m *Model) submitMany(fields []string) tea.Cmd {
+	var outcomes []fetchOutcome
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, `<>()[]{},;"'`)
+		if !strings.Contains(field, "://") && !githuburl.IsRepoShorthand(field) {
+			continue
+		}
+		parsed, err := githuburl.Parse(field)
+		if err != nil {
+			outcomes = append(outcomes, fetchOutcome{Label: field, Err: err})
+			continue
+		}
+		if seen[parsed.Key()] {
+			continue
+		}
+		seen[parsed.Key()] = true
+		outcomes = append(outcomes, fetchOutcome{
+			Label:  parsed.String(),
+			Source: parsed,
+			Client: m.clientFor(parsed.Host),
+		})
+	}
+	if len(outcomes) == 0 {
+		m.setStatus("No GitHub references found", statusError)
+		return nil
+	}
+
+	m.pendingFetch = true
+	m.setStatus(fmt.Sprintf("Watching %d references …", len(outcomes)), statusNeutral)
+	return fetchManyCmd(outcomes, m.concurrency)
+}
+
+// fetchManyCmd fetches every parsed outcome and reports them together so the
+// status line can summarize the batch.
+func fetchManyCmd(outcomes []fetchOutcome, concurrency int) tea.Cmd {
+	return func() tea.Msg {
+		fetchOutcomes(outcomes, concurrency)
+		return fetchManyResultMsg{Outcomes: outcomes}
+	}
+}
+
+// fetchOutcomes fills in the runs of every outcome without an error yet, at
+// most concurrency at a time.
+func fetchOutcomes(outcomes []fetchOutcome, concurrency int) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(1, concurrency))
+	for i := range outcomes {
+		if outcomes[i].Err != nil {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(outcome *fetchOutcome) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			outcome.Runs, outcome.Err = fetchSourceRuns(ctx, outcome.Client, outcome.Source, 0)
+		}(&outcomes[i])
+	}
+	wg.Wait()
+}
+
+// absorbFetchMany adds the runs of every successful outcome, then replaces
+// the per-source status messages with one summary of the batch.
+func (m *Model) absorbFetchMany(msg fetchManyResultMsg) tea.Cmd {
+	var (
+		cmds     []tea.Cmd
+		failures []string
+	)
+	for _, outcome := range msg.Outcomes {
+		if outcome.Err != nil {
+			m.noteRateLimit(outcome.Err)
+			failures = append(failures, fmt.Sprintf("%s: %v", outcome.Label, outcome.Err))
+			continue
+		}
+		cmds = append(cmds, m.absorbFetch(fetchResultMsg{Runs: outcome.Runs, Source: outcome.Source}))
+	}
+
+	total := len(msg.Outcomes)
+	switch {
+	case len(failures) == 0:
+		m.setStatus(fmt.Sprintf("Watching all %d references", total), statusSuccess)
+	default:
+		m.setStatus(fmt.Sprintf("Watching %d/%d references; failed: %s", total-len(failures), total, strings.Join(failures, "; ")), statusError)
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
+)
+
+const (
+	defaultConcurrency    = 4
+	defaultRequestTimeout = 15 * time.Second
+)
+
+// refreshJob is one unit of work in a refresh batch: a single run or a PR or
+// branch source, fetched by fetch, or a batch handed to a batchRefresher,
+// whose plan returns the jobs (at most planSize) for what changed. Both apply
+// timeout to each request they make.
+type refreshJob struct {
+	label    string
+	fetch    func(ctx context.Context, timeout time.Duration) (refreshResultMsg, error)
+	plan     func(ctx context.Context, timeout time.Duration) ([]refreshJob, error)
+	planSize int
+}
+
+func runRefreshJob(input refreshInput) refreshJob {
+	return refreshJob{
+		label: fmt.Sprintf("%s/%s #%d", input.Owner, input.Repo, input.RunID),
+		fetch: func(ctx context.Context, timeout time.Duration) (refreshResultMsg, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			run, err := input.Client.WorkflowRunByID(ctx, input.Owner, input.Repo, input.RunID)
+			if err != nil {
+				return refreshResultMsg{}, err
+			}
+			return refreshResultMsg{Runs: []githubclient.WorkflowRun{run}}, nil
+		},
+	}
+}
+
+func sourceRefreshJob(client githubAPI, source githuburl.Parsed, since int64) refreshJob {
+	return refreshJob{
+		label: source.String(),
+		fetch: func(ctx context.Context, timeout time.Duration) (refreshResultMsg, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			runs, err := fetchSourceRuns(ctx, client, source, since)
+			if err != nil {
+				return refreshResultMsg{}, err
+			}
+			return refreshResultMsg{SourceRuns: map[githuburl.Parsed][]githubclient.WorkflowRun{source: runs}}, nil
+		},
+	}
+}
+
+// startRefresh runs jobs on a bounded worker pool. Each successful job is
+// delivered to the model as its own refreshResultMsg as soon as it finishes;
+// a final message with done set carries the collected errors. Every message
+// of a scheduled (auto) batch is tagged so the model can tell it apart from
+// a manual refresh running alongside.
+func (m *Model) startRefresh(jobs []refreshJob, auto bool) tea.Cmd {
+	// Buffered so workers never block on a model that stopped listening.
+	capacity := 1
+	for _, job := range jobs {
+		if job.plan != nil {
+			capacity += job.planSize
+		} else {
+			capacity++
+		}
+	}
+	results := make(chan refreshResultMsg, capacity)
+	go runRefreshPool(jobs, m.concurrency, m.requestTimeout, auto, results)
+	return waitForRefresh(results)
+}
+
+func waitForRefresh(results <-chan refreshResultMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-results
+		if !ok {
+			return refreshResultMsg{done: true}
+		}
+		return msg
+	}
+}
+
+func runRefreshPool(jobs []refreshJob, concurrency int, timeout time.Duration, auto bool, results chan refreshResultMsg) {
+	defer close(results)
+
+	// Cancelled when GitHub rate limits us, so queued jobs don't pile on.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		errs        []string
+		rateLimited time.Time
+	)
+	fail := func(label string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+			// Aborted because another job hit the rate limit.
+			return
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", label, err))
+		var rateErr *githubclient.RateLimitError
+		if errors.As(err, &rateErr) {
+			if rateErr.RetryAt.After(rateLimited) {
+				rateLimited = rateErr.RetryAt
+			}
+			cancel()
+		}
+	}
+	sem := make(chan struct{}, max(1, concurrency))
+	each := func(jobs []refreshJob, work func(job refreshJob)) {
+		for _, job := range jobs {
+			sem <- struct{}{}
+			if ctx.Err() != nil {
+				<-sem
+				break
+			}
+			wg.Add(1)
+			go func(job refreshJob) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				work(job)
+			}(job)
+		}
+		wg.Wait()
+	}
+
+	// Batches only work out what changed; the jobs they plan join the
+	// others, so they are fetched just as concurrently and delivered as
+	// they finish.
+	var batches, fetches []refreshJob
+	for _, job := range jobs {
+		if job.plan != nil {
+			batches = append(batches, job)
+		} else {
+			fetches = append(fetches, job)
+		}
+	}
+	each(batches, func(job refreshJob) {
+		planned, err := job.plan(ctx, timeout)
+		mu.Lock()
+		fetches = append(fetches, planned...)
+		mu.Unlock()
+		if err != nil {
+			fail(job.label, err)
+		}
+	})
+	each(fetches, func(job refreshJob) {
+		msg, err := job.fetch(ctx, timeout)
+		if len(msg.Runs) > 0 || len(msg.SourceRuns) > 0 {
+			msg.more = results
+			msg.auto = auto
+			results <- msg
+		}
+		if err != nil {
+			fail(job.label, err)
+		}
+	})
+
+	final := refreshResultMsg{done: true, auto: auto, RateLimitedUntil: rateLimited}
+	if len(errs) > 0 {
+		final.Err = errors.New(strings.Join(errs, "; "))
+	}
+	results <- final
+}
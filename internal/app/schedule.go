@@ -0,0 +1,193 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/watch"
+)
+
+const (
+	// A run that just started often fails fast (lint, setup errors), and one
+	// nearing its usual duration is about to finish: poll those quickly.
+	freshRunWindow = 2 * time.Minute
+	nearFinishRate = 0.8
+	// Long queues and long runs barely change between polls.
+	staleQueueAfter = 10 * time.Minute
+	minPollInterval = 2 * time.Second
+	maxPollInterval = 5 * time.Minute
+	// After a rerun or cancel, keep polling the run until GitHub reflects it,
+	// but give up eventually in case the action never takes effect.
+	followUpWindow = 2 * time.Minute
+)
+
+// pollSchedule remembers when each run (and each PR source) is next due, so
+// every tracked run can be polled on its own cadence.
+type pollSchedule struct {
+	runs      map[int64]time.Time
+	sources   map[string]time.Time
+	followUps map[int64]followUp
+}
+
+// followUp is a run the user just acted on, remembered as it looked before
+// the action so polling continues until its attempt or status moves.
+type followUp struct {
+	attempt int
+	status  githubclient.RunStatus
+	until   time.Time
+}
+
+func newPollSchedule() pollSchedule {
+	return pollSchedule{
+		runs:      make(map[int64]time.Time),
+		sources:   make(map[string]time.Time),
+		followUps: make(map[int64]followUp),
+	}
+}
+
+func (s pollSchedule) runDue(id int64, now time.Time) bool {
+	next, ok := s.runs[id]
+	return !ok || !now.Before(next)
+}
+
+func (s pollSchedule) sourceDue(key string, now time.Time) bool {
+	next, ok := s.sources[key]
+	return !ok || !now.Before(next)
+}
+
+// nextPollAt returns when a run will next be polled; zero means never (the run
+// finished and is only refreshed on demand).
+func (m *Model) nextPollAt(run *watch.TrackedRun) time.Time {
+	if !m.polled(run) {
+		return time.Time{}
+	}
+	return m.schedule.runs[run.Run.ID]
+}
+
+// polled reports whether a run is on the poll schedule: it is still going, or
+// an action against it hasn't shown up yet.
+func (m *Model) polled(run *watch.TrackedRun) bool {
+	if !run.Run.Status.Completed() {
+		return true
+	}
+	_, ok := m.schedule.followUps[run.Run.ID]
+	return ok
+}
+
+// followUpRun makes a run due right away and keeps it on the schedule, even
+// if it already finished, until its attempt or status changes.
+func (m *Model) followUpRun(run *watch.TrackedRun, now time.Time) {
+	m.schedule.followUps[run.Run.ID] = followUp{
+		attempt: run.Run.Attempt,
+		status:  run.Run.Status,
+		until:   now.Add(followUpWindow),
+	}
+	m.schedule.runs[run.Run.ID] = now
+}
+
+// scheduleRun picks the next poll time for a run from its current state.
+func (m *Model) scheduleRun(run *watch.TrackedRun, now time.Time) {
+	if pending, ok := m.schedule.followUps[run.Run.ID]; ok {
+		changed := run.Run.Attempt != pending.attempt || run.Run.Status != pending.status
+		if !changed && now.Before(pending.until) {
+			interval := m.pollInterval / 2
+			if interval < minPollInterval {
+				interval = minPollInterval
+			}
+			m.schedule.runs[run.Run.ID] = now.Add(interval)
+			return
+		}
+		delete(m.schedule.followUps, run.Run.ID)
+	}
+	if run.Run.Status.Completed() {
+		delete(m.schedule.runs, run.Run.ID)
+		return
+	}
+	m.schedule.runs[run.Run.ID] = now.Add(m.pollIntervalFor(run, now))
+}
+
+// pollIntervalFor adapts the base poll interval to where the run is in its
+// lifecycle, using the durations of earlier runs of the same workflow as a
+// guide.
+func (m *Model) pollIntervalFor(run *watch.TrackedRun, now time.Time) time.Duration {
+	base := m.pollInterval
+	interval := base
+
+	queued := run.Run.StartedAt.IsZero() || strings.HasPrefix(run.Run.StatusDetail, "queued")
+	switch {
+	case queued && !run.Run.CreatedAt.IsZero() && now.Sub(run.Run.CreatedAt) > staleQueueAfter:
+		interval = base * 6
+	case queued:
+		interval = base
+	case now.Sub(run.Run.StartedAt) < freshRunWindow:
+		interval = base / 2
+	default:
+		elapsed := now.Sub(run.Run.StartedAt)
+		if expected := m.expectedDuration(run); expected > 0 {
+			switch {
+			case elapsed >= time.Duration(float64(expected)*nearFinishRate):
+				interval = base / 2
+			case elapsed < expected/2:
+				interval = base * 3
+			}
+		}
+	}
+
+	if interval < minPollInterval {
+		interval = minPollInterval
+	}
+	if interval > maxPollInterval && base < maxPollInterval {
+		interval = maxPollInterval
+	}
+	return interval
+}
+
+// expectedDuration is the median duration of finished runs of the same
+// workflow in the same repository, or zero without history.
+func (m *Model) expectedDuration(run *watch.TrackedRun) time.Duration {
+	var durations []time.Duration
+	for _, archived := range []bool{false, true} {
+		for _, other := range m.tracker.VisibleRuns(archived) {
+			if other.Run.ID == run.Run.ID || !other.Run.Status.Completed() {
+				continue
+			}
+			if other.Run.RepoFullName != run.Run.RepoFullName || other.Run.WorkflowName != run.Run.WorkflowName {
+				continue
+			}
+			if other.Run.StartedAt.IsZero() || !other.Run.LastUpdatedAt.After(other.Run.StartedAt) {
+				continue
+			}
+			durations = append(durations, other.Run.LastUpdatedAt.Sub(other.Run.StartedAt))
+		}
+	}
+	if len(durations) == 0 {
+		return 0
+	}
+	slices.Sort(durations)
+	return durations[len(durations)/2]
+}
+
+// schedulerTick is how often the model checks for due runs. It is finer than
+// the poll interval so that faster per-run cadences are honoured.
+func (m *Model) schedulerTick() time.Duration {
+	tick := m.pollInterval / 4
+	if tick < time.Second {
+		tick = time.Second
+	}
+	if tick > m.pollInterval {
+		tick = m.pollInterval
+	}
+	return tick
+}
+
+// rescheduleRuns recomputes the next poll for freshly fetched runs.
+func (m *Model) rescheduleRuns(runs []githubclient.WorkflowRun) {
+	now := time.Now()
+	for _, run := range runs {
+		if tracked := m.tracker.Get(run.ID); tracked != nil {
+			m.scheduleRun(tracked, now)
+		}
+	}
+}
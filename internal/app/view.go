@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/nateberkopec/ghwatch/internal/githubclient"
+	"github.com/nateberkopec/ghwatch/internal/githuburl"
 	"github.com/nateberkopec/ghwatch/internal/watch"
 )
 
@@ -55,7 +56,14 @@ func renderView(m *Model) string {
 	var out []string
 	out = append(out, renderInputField(m))
 	out = append(out, renderHelpText(m))
-	out = append(out, renderRunsTable(m))
+	if m.logs.open {
+		out = append(out, renderLogView(m))
+	} else {
+		out = append(out, renderRunsTable(m))
+		if m.detail.open {
+			out = append(out, renderDetailPane(m))
+		}
+	}
 	out = append(out, renderStatusLine(m))
 
 	return strings.Join(out, "\n")
@@ -71,7 +79,7 @@ func renderHeader(m *Model) string {
 }
 
 func renderRunsTable(m *Model) string {
-	runs := m.tracker.VisibleRuns(m.showArchived)
+	rows := m.listRows()
 	widths := calculateColumnWidths(m.width)
 
 	builder := strings.Builder{}
@@ -80,7 +88,7 @@ func renderRunsTable(m *Model) string {
 
 	dataRows := m.dataRows()
 
-	if len(runs) == 0 {
+	if len(rows) == 0 {
 		linesUsed := 1
 		for linesUsed < m.listArea.height {
 			builder.WriteString("\n")
@@ -91,12 +99,21 @@ func renderRunsTable(m *Model) string {
 	}
 
 	start := m.scrollOffset
-	end := min(start+dataRows, len(runs))
+	end := min(start+dataRows, len(rows))
 	linesUsed := 1
 
 	for idx := start; idx < end; idx++ {
 		builder.WriteString("\n")
-		row := tableRowData(runs[idx])
+		var row []string
+		switch {
+		case rows[idx].header():
+			row = groupRowData(rows[idx].group, m.expanded[rows[idx].group.key])
+		case rows[idx].group != nil:
+			row = tableRowData(rows[idx].run)
+			row[4] = "  " + row[4] // Indent runs under their group header
+		default:
+			row = tableRowData(rows[idx].run)
+		}
 		rowStr := renderRow(row, widths, rowStyle)
 		if idx == m.selectedIndex && m.focus == focusRuns {
 			rowStr = selectedRowStyle.Width(m.width).Render(rowStr)
@@ -115,8 +132,40 @@ func renderRunsTable(m *Model) string {
 }
 
 func renderHelpText(m *Model) string {
+	if preview := renderInputPreview(m); preview != "" {
+		return preview
+	}
 	help := "[tab] focus • [o] open • [a] archive/restore • [A] view archived • [b] bell • [q] quit"
-	return helpStyle.Width(m.width).Render(pad(help, m.width))
+	if m.grouped {
+		help = "[space] expand/collapse • [o] open • [a] archive/restore • [v] ungroup • [q] quit"
+	}
+	if m.logs.open {
+		help = "[esc] close • [/] search • [n/N] match • [e] first error • [t] timestamps • [c] ANSI"
+	} else if m.detail.open {
+		help = "[d] hide details • [ / ] select job • [l] job log • [n] annotations • [o] open"
+		if m.detail.mode == detailAnnotations {
+			help = "[n] show jobs • [ / ] select annotation • [y] copy file:line • [d] hide details"
+		}
+	}
+	return helpStyle.Width(m.width).Render(pad(truncate(help, m.width), m.width))
+}
+
+// renderInputPreview replaces the help line with what the input currently
+// parses as while the user types, so short forms can be checked before enter.
+func renderInputPreview(m *Model) string {
+	value := strings.TrimSpace(m.input.Value())
+	if m.focus != focusInput || value == "" {
+		return ""
+	}
+	style := statusSuccessStyle
+	preview := "→ "
+	if parsed, err := githuburl.Parse(value); err != nil {
+		style = statusErrorStyle
+		preview += err.Error()
+	} else {
+		preview += parsed.String()
+	}
+	return style.Width(m.width).Render(pad(truncate(preview, m.width), m.width))
 }
 
 func renderStatusLine(m *Model) string {
@@ -133,6 +182,11 @@ func renderStatusLine(m *Model) string {
 		style = statusSuccessStyle
 	}
 
+	if m.confirm != nil {
+		prompt := fmt.Sprintf("%s [y/N]", m.confirm.prompt)
+		return statusErrorStyle.Width(m.width).Render(pad(truncate(prompt, m.width), m.width))
+	}
+
 	if m.refreshing {
 		refreshLabel := fmt.Sprintf("auto-refresh %s", m.spin.View())
 		if msg == "" {
@@ -142,9 +196,28 @@ func renderStatusLine(m *Model) string {
 		}
 	}
 
+	if quota := renderQuota(m); quota != "" {
+		if msg == "" {
+			msg = quota
+		} else {
+			msg = fmt.Sprintf("%s   %s", msg, quota)
+		}
+	}
+
 	return style.Width(m.width).Render(pad(msg, m.width))
 }
 
+func renderQuota(m *Model) string {
+	if m.rateLimited() {
+		return fmt.Sprintf("rate limited • polling paused until %s", m.pausedUntil.Local().Format("15:04:05"))
+	}
+	limit := m.client.RateLimit()
+	if limit.Limit == 0 {
+		return ""
+	}
+	return fmt.Sprintf("API %d/%d", limit.Remaining, limit.Limit)
+}
+
 func renderInputField(m *Model) string {
 	view := m.input.View()
 	if m.focus == focusInput {
@@ -163,30 +236,232 @@ func tableHeaders() []string {
 
 func tableRowData(run *watch.TrackedRun) []string {
 	owner, repo := splitRepo(run.Run.RepoFullName)
+	name := run.Run.Name
+	if attempt := attemptLabel(run); attempt != "" {
+		name = fmt.Sprintf("%s (%s)", name, attempt)
+	}
+	target := run.Run.Target
+	if run.Superseded {
+		target = fmt.Sprintf("%s (superseded)", target)
+	}
 	data := []string{
 		formatStatus(run.Run),
 		repo,
 		owner,
-		run.Run.Target,
-		run.Run.Name,
+		target,
+		name,
 		run.Run.WorkflowName,
 	}
 	return data
 }
 
+// attemptLabel describes reruns, e.g. "attempt 2, prev ❌". It is empty for
+// first attempts.
+func attemptLabel(run *watch.TrackedRun) string {
+	if run.Run.Attempt <= 1 {
+		return ""
+	}
+	label := fmt.Sprintf("attempt %d", run.Run.Attempt)
+	if prev := run.PreviousAttempt(); prev != nil {
+		label = fmt.Sprintf("%s, prev %s", label, statusIcon(prev.Status))
+	}
+	return label
+}
+
 func formatStatus(run githubclient.WorkflowRun) string {
-	switch run.Status {
+	return statusStyle(run.Status).Render(statusIcon(run.Status))
+}
+
+func statusIcon(status githubclient.RunStatus) string {
+	switch status {
 	case githubclient.RunStatusSuccess:
 		return "✅"
 	case githubclient.RunStatusFailed:
 		return "❌"
-	case githubclient.RunStatusPending:
-		return "⏳"
+	case githubclient.RunStatusCancelled:
+		return "🚫"
+	case githubclient.RunStatusSkipped:
+		return "⏩"
+	case githubclient.RunStatusNeutral:
+		return "⚪"
+	case githubclient.RunStatusWaiting:
+		return "✋"
+	case githubclient.RunStatusActionRequired:
+		return "❗"
 	default:
 		return "⏳"
 	}
 }
 
+// statusStyle colors status text (e.g. "completed/cancelled") to match the
+// icon, so states that share a family still read differently at a glance.
+func statusStyle(status githubclient.RunStatus) lipgloss.Style {
+	switch status {
+	case githubclient.RunStatusSuccess:
+		return statusSuccessStyle
+	case githubclient.RunStatusFailed:
+		return statusErrorStyle
+	case githubclient.RunStatusCancelled:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+	case githubclient.RunStatusSkipped, githubclient.RunStatusNeutral:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	case githubclient.RunStatusWaiting, githubclient.RunStatusActionRequired:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+	}
+}
+
+func renderDetailPane(m *Model) string {
+	height := m.detailArea.height
+	lines := detailLines(m)
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, line := range lines {
+		lines[i] = pad(truncate(line, m.width), m.width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func detailLines(m *Model) []string {
+	run := m.selectedRun()
+	if run == nil {
+		return []string{headerStyle.Render("Details"), helpStyle.Render("Select a run to see its details")}
+	}
+	if m.detail.mode == detailAnnotations {
+		return annotationLines(m, run)
+	}
+	title := headerStyle.Render(fmt.Sprintf("Jobs • %s • ", run.Run.Name)) + statusStyle(run.Run.Status).Render(run.Run.StatusDetail)
+	if attempt := attemptLabel(run); attempt != "" {
+		title = fmt.Sprintf("%s%s", title, headerStyle.Render(" • "+attempt))
+	}
+	if next := m.nextPollAt(run); !next.IsZero() {
+		title = fmt.Sprintf("%s%s", title, helpStyle.Render(" • next poll "+formatUntil(next, time.Now())))
+	}
+	lines := []string{title}
+	for _, prev := range run.Attempts {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf("  attempt %d: %s %s", prev.Number, statusIcon(prev.Status), prev.StatusDetail)))
+	}
+
+	switch {
+	case m.detail.err != nil:
+		return append(lines, statusErrorStyle.Render(m.detail.err.Error()))
+	case m.detail.loading && len(m.detail.jobs) == 0:
+		return append(lines, helpStyle.Render("Loading jobs…"))
+	case len(m.detail.jobs) == 0:
+		return append(lines, helpStyle.Render("No jobs reported yet"))
+	}
+
+	now := time.Now()
+	for i, job := range m.detail.jobs {
+		line := fmt.Sprintf("%s %s", statusIcon(job.Status), job.Name)
+		if job.Status != githubclient.RunStatusSuccess && job.Status != githubclient.RunStatusFailed {
+			// Icons alone don't say whether a job is queued or mid-run.
+			line = fmt.Sprintf("%s  %s", line, job.StatusDetail)
+		}
+		if d := formatSpan(job.StartedAt, job.CompletedAt, now); d != "" {
+			line = fmt.Sprintf("%s  %s", line, d)
+		}
+		if job.RunnerName != "" {
+			line = fmt.Sprintf("%s  on %s", line, job.RunnerName)
+		}
+		if i != m.detail.jobIndex {
+			lines = append(lines, "  "+line)
+			continue
+		}
+		lines = append(lines, selectedRowStyle.Render("› "+line))
+		for _, step := range job.Steps {
+			stepLine := fmt.Sprintf("      %s %d. %s", statusIcon(step.Status), step.Number, step.Name)
+			if d := formatSpan(step.StartedAt, step.CompletedAt, now); d != "" {
+				stepLine = fmt.Sprintf("%s  %s", stepLine, d)
+			}
+			lines = append(lines, stepLine)
+		}
+	}
+	return lines
+}
+
+func annotationLines(m *Model, run *watch.TrackedRun) []string {
+	title := fmt.Sprintf("Annotations • %s • %s", run.Run.Name, run.Run.StatusDetail)
+	lines := []string{headerStyle.Render(title)}
+
+	switch {
+	case m.detail.err != nil:
+		return append(lines, statusErrorStyle.Render(m.detail.err.Error()))
+	case m.detail.loading && len(m.detail.annotations) == 0:
+		return append(lines, helpStyle.Render("Loading annotations…"))
+	case len(m.detail.annotations) == 0:
+		return append(lines, helpStyle.Render("No annotations reported"))
+	}
+
+	for i, a := range m.detail.annotations {
+		message := strings.Join(strings.Fields(firstNonEmpty(a.Title, a.Message)), " ")
+		line := fmt.Sprintf("%s %s  %s  (%s)", annotationIcon(a.Level), a.Location(), message, a.CheckRunName)
+		if i == m.detail.annotationIndex {
+			lines = append(lines, selectedRowStyle.Render("› "+line))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+func annotationIcon(level string) string {
+	switch level {
+	case "failure":
+		return "❌"
+	case "warning":
+		return "🟡"
+	default:
+		return "🔵"
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// formatSpan renders how long something ran, counting up to now while it is
+// still in progress.
+func formatSpan(started, completed, now time.Time) string {
+	if started.IsZero() {
+		return ""
+	}
+	end := completed
+	if end.IsZero() || end.Before(started) {
+		end = now
+	}
+	return formatDuration(end.Sub(started))
+}
+
+// formatUntil describes a future moment relative to now, e.g. "in 12s".
+func formatUntil(t, now time.Time) string {
+	if !t.After(now) {
+		return "due"
+	}
+	return "in " + formatDuration(t.Sub(now))
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
 func renderRow(cells []string, widths []int, style lipgloss.Style) string {
 	// Only include columns with non-zero widths
 	var parts []string
@@ -2,6 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,6 +16,7 @@ import (
 
 	"github.com/nateberkopec/ghwatch/internal/githubclient"
 	"github.com/nateberkopec/ghwatch/internal/githuburl"
+	"github.com/nateberkopec/ghwatch/internal/watch"
 )
 
 func TestViewSnapshot(t *testing.T) {
@@ -54,6 +61,125 @@ func TestViewSnapshot(t *testing.T) {
 	snaps.MatchSnapshot(t, m.View())
 }
 
+func TestDetailPaneSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, BellEnabled: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m = updated.(*Model)
+
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{
+			ID:           1,
+			Name:         "unit",
+			WorkflowName: "CI",
+			RepoFullName: "example/api",
+			Target:       "PR #12",
+			Status:       githubclient.RunStatusFailed,
+			StatusDetail: "completed/failure",
+		},
+	}, githuburl.Parsed{Kind: githuburl.KindPullRequest, Owner: "example", Repo: "api", PRNumber: 12})
+
+	cmd := m.toggleDetail()
+	if cmd == nil {
+		t.Fatal("expected opening the detail pane to fetch jobs")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(*Model)
+
+	if job := m.selectedJob(); job == nil || job.Name != "test" {
+		t.Fatalf("expected failed job to be preselected, got %#v", job)
+	}
+
+	snaps.MatchSnapshot(t, m.View())
+}
+
+func TestDetailPaneReloadsAtMostOncePerInterval(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, PollInterval: time.Minute})
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, Name: "unit", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusPending},
+		{ID: 2, Name: "lint", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusSuccess},
+	}, githuburl.Parsed{})
+	m.selectRun(1)
+	if m.toggleDetail() == nil {
+		t.Fatal("expected opening the detail pane to fetch jobs")
+	}
+	if m.refreshDetailCmd(false) != nil {
+		t.Fatal("expected no reload right after the pane loaded")
+	}
+	m.detail.fetchedAt = time.Now().Add(-time.Minute)
+	if m.refreshDetailCmd(false) == nil {
+		t.Fatal("expected a reload once the poll interval passed")
+	}
+
+	m.selectRun(2)
+	m.syncDetail()
+	m.detail.fetchedAt = time.Now().Add(-time.Hour)
+	if m.refreshDetailCmd(false) != nil {
+		t.Fatal("expected a finished run not to be reloaded on ticks")
+	}
+	if m.refreshDetailCmd(true) == nil {
+		t.Fatal("expected a forced refresh to reload a finished run")
+	}
+}
+
+func TestLogViewSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, BellEnabled: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m = updated.(*Model)
+
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, Name: "unit", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusFailed},
+	}, githuburl.Parsed{})
+	updated, _ = m.Update(m.toggleDetail()())
+	m = updated.(*Model)
+
+	cmd := m.openLogs()
+	if cmd == nil {
+		t.Fatal("expected log download to start for the selected job")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(*Model)
+
+	if !m.logs.stripTimestamps {
+		t.Fatal("expected t to toggle timestamp stripping")
+	}
+
+	snaps.MatchSnapshot(t, m.View())
+}
+
+func TestAnnotationsPaneSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, BellEnabled: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m = updated.(*Model)
+
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, Name: "unit", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusFailed, StatusDetail: "completed/failure", CheckSuiteID: 77},
+	}, githuburl.Parsed{})
+
+	updated, _ = m.Update(m.toggleAnnotations()())
+	m = updated.(*Model)
+	m.moveDetail(1)
+
+	if a := m.selectedAnnotation(); a == nil || a.Location() != "api_test.go:40" {
+		t.Fatalf("expected second annotation to be selected, got %#v", a)
+	}
+
+	snaps.MatchSnapshot(t, m.View())
+}
+
 type stubGitHubClient struct{}
 
 func (stubGitHubClient) WorkflowRunByID(_ context.Context, _, _ string, _ int64) (githubclient.WorkflowRun, error) {
@@ -67,3 +193,1055 @@ func (stubGitHubClient) RunsByPullRequest(_ context.Context, _, _ string, _ int)
 func (stubGitHubClient) RunsByCommit(_ context.Context, _, _, _ string) ([]githubclient.WorkflowRun, error) {
 	return nil, nil
 }
+
+func (stubGitHubClient) RunsByBranch(_ context.Context, _, _, _ string) ([]githubclient.WorkflowRun, error) {
+	return nil, nil
+}
+
+func (stubGitHubClient) OpenPullRequestForBranch(_ context.Context, _, _, _ string) (int, error) {
+	return 0, nil
+}
+
+func (stubGitHubClient) SearchPullRequests(_ context.Context, _ string) ([]githubclient.PullRequestRef, bool, error) {
+	return nil, false, nil
+}
+
+func (stubGitHubClient) PullRequestOpen(_ context.Context, _, _ string, _ int) (bool, error) {
+	return true, nil
+}
+
+func (stubGitHubClient) RecentRuns(_ context.Context, _, _ string, _ githubclient.RunFilter) ([]githubclient.WorkflowRun, error) {
+	return nil, nil
+}
+
+func (stubGitHubClient) RunsByWorkflow(_ context.Context, _, _, _ string) ([]githubclient.WorkflowRun, error) {
+	return nil, nil
+}
+
+func (stubGitHubClient) JobByID(_ context.Context, _, _ string, jobID int64) (githubclient.Job, error) {
+	return githubclient.Job{ID: jobID, RunID: 42}, nil
+}
+
+func (stubGitHubClient) JobsForRun(_ context.Context, _, _ string, runID int64) ([]githubclient.Job, error) {
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []githubclient.Job{
+		{
+			ID:          10,
+			RunID:       runID,
+			Name:        "build",
+			Status:      githubclient.RunStatusSuccess,
+			RunnerName:  "ubuntu-1",
+			StartedAt:   started,
+			CompletedAt: started.Add(42 * time.Second),
+		},
+		{
+			ID:          11,
+			RunID:       runID,
+			Name:        "test",
+			Status:      githubclient.RunStatusFailed,
+			RunnerName:  "ubuntu-2",
+			StartedAt:   started,
+			CompletedAt: started.Add(3*time.Minute + 5*time.Second),
+			Steps: []githubclient.Step{
+				{Number: 1, Name: "checkout", Status: githubclient.RunStatusSuccess, StartedAt: started, CompletedAt: started.Add(2 * time.Second)},
+				{Number: 2, Name: "go test", Status: githubclient.RunStatusFailed, StartedAt: started, CompletedAt: started.Add(3 * time.Minute)},
+			},
+		},
+	}, nil
+}
+
+func (stubGitHubClient) JobLogs(_ context.Context, _, _ string, _ int64) (string, error) {
+	return "2025-01-01T12:00:00.0000000Z Run go test ./...\n" +
+		"2025-01-01T12:00:01.0000000Z \x1b[32mok\x1b[0m example/api/internal\n" +
+		"2025-01-01T12:00:02.0000000Z ##[error]Process completed with exit code 1.\n", nil
+}
+
+func (stubGitHubClient) AnnotationsForCheckSuite(_ context.Context, _, _ string, _ int64) ([]githubclient.Annotation, error) {
+	return []githubclient.Annotation{
+		{CheckRunName: "lint", Path: "cmd/main.go", StartLine: 12, Level: "failure", Message: "unused variable x"},
+		{CheckRunName: "test", Path: "api_test.go", StartLine: 40, Level: "warning", Title: "slow test"},
+	}, nil
+}
+
+func (stubGitHubClient) RerunRun(_ context.Context, _, _ string, _ int64) error {
+	return nil
+}
+
+func (stubGitHubClient) RerunFailedJobs(_ context.Context, _, _ string, _ int64) error {
+	return nil
+}
+
+func (stubGitHubClient) CancelRun(_ context.Context, _, _ string, _ int64) error {
+	return nil
+}
+
+func (stubGitHubClient) RateLimit() githubclient.RateLimit {
+	return githubclient.RateLimit{}
+}
+
+func TestRerunRequiresConfirmation(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, AllowWrite: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m = updated.(*Model)
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, Name: "unit", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusFailed},
+	}, githuburl.Parsed{})
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(*Model)
+	if m.confirm == nil {
+		t.Fatal("expected rerun to wait for confirmation")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(*Model)
+	if m.confirm != nil || cmd == nil {
+		t.Fatal("expected confirmation to dispatch the rerun")
+	}
+	result, ok := cmd().(actionResultMsg)
+	if !ok || result.Err != nil || result.RunID != 1 {
+		t.Fatalf("unexpected action result: %#v", result)
+	}
+}
+
+func TestRerunKeepsFinishedRunPolledUntilItChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, AllowWrite: true})
+	failed := githubclient.WorkflowRun{ID: 1, Name: "unit", WorkflowName: "CI", RepoFullName: "example/api", Status: githubclient.RunStatusFailed, Attempt: 1}
+	m.absorbRuns([]githubclient.WorkflowRun{failed}, githuburl.Parsed{})
+	run := m.tracker.Get(1)
+	if !m.nextPollAt(run).IsZero() {
+		t.Fatal("expected a finished run to be off the schedule")
+	}
+
+	m.Update(actionResultMsg{RunID: 1, Label: "Re-run requested"})
+	if m.nextPollAt(run).IsZero() {
+		t.Fatal("expected the rerun run to be polled")
+	}
+
+	// GitHub still reports the old attempt: keep polling.
+	m.Update(refreshResultMsg{Runs: []githubclient.WorkflowRun{failed}})
+	if m.nextPollAt(m.tracker.Get(1)).IsZero() {
+		t.Fatal("expected polling to continue until the new attempt shows up")
+	}
+
+	// The new attempt finishes: the follow-up is over.
+	rerun := failed
+	rerun.Attempt = 2
+	rerun.Status = githubclient.RunStatusSuccess
+	m.Update(refreshResultMsg{Runs: []githubclient.WorkflowRun{rerun}})
+	if !m.nextPollAt(m.tracker.Get(1)).IsZero() {
+		t.Fatal("expected the finished new attempt to leave the schedule")
+	}
+}
+
+func TestAttemptLabel(t *testing.T) {
+	run := &watch.TrackedRun{Run: githubclient.WorkflowRun{Attempt: 1}}
+	if label := attemptLabel(run); label != "" {
+		t.Fatalf("expected no label for first attempt, got %q", label)
+	}
+
+	run.Run.Attempt = 2
+	run.Attempts = []watch.Attempt{{Number: 1, Status: githubclient.RunStatusFailed}}
+	if label := attemptLabel(run); label != "attempt 2, prev ❌" {
+		t.Fatalf("unexpected attempt label: %q", label)
+	}
+}
+
+// countingClient records which runs get refreshed by ID.
+type countingClient struct {
+	stubGitHubClient
+	mu        *sync.Mutex
+	refreshed *[]int64
+}
+
+func (c countingClient) WorkflowRunByID(_ context.Context, _, _ string, runID int64) (githubclient.WorkflowRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.refreshed = append(*c.refreshed, runID)
+	return githubclient.WorkflowRun{ID: runID, RepoFullName: "example/api"}, nil
+}
+
+// slowClient blocks refreshes of run 1 until the request times out and
+// records how many refreshes were in flight at once.
+type slowClient struct {
+	stubGitHubClient
+	mu       *sync.Mutex
+	inFlight *int
+	peak     *int
+}
+
+func (c slowClient) WorkflowRunByID(ctx context.Context, _, _ string, runID int64) (githubclient.WorkflowRun, error) {
+	c.mu.Lock()
+	*c.inFlight++
+	if *c.inFlight > *c.peak {
+		*c.peak = *c.inFlight
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		*c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	if runID == 1 {
+		<-ctx.Done()
+		return githubclient.WorkflowRun{}, ctx.Err()
+	}
+	time.Sleep(5 * time.Millisecond)
+	return githubclient.WorkflowRun{ID: runID, RepoFullName: "example/api", Status: githubclient.RunStatusSuccess}, nil
+}
+
+// noGraphQLClient is a slowClient on a server without GraphQL.
+type noGraphQLClient struct {
+	slowClient
+	lookups *int
+}
+
+func (c noGraphQLClient) RunStates(_ context.Context, _ []string) (map[string]githubclient.RunState, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.lookups++
+	return nil, fmt.Errorf("%w: not found", githubclient.ErrGraphQL)
+}
+
+func (c noGraphQLClient) PullRequestHeads(_ context.Context, _ []githubclient.PullRequestRef) (map[githubclient.PullRequestRef]githubclient.PullRequestHead, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.lookups++
+	return nil, fmt.Errorf("%w: not found", githubclient.ErrGraphQL)
+}
+
+// drainRefresh feeds a refresh batch through the model, following the
+// commands Update returns, until its final message. It returns how many
+// partial results arrived before it.
+func drainRefresh(t *testing.T, m *Model, cmd tea.Cmd) (partials int, final refreshResultMsg) {
+	t.Helper()
+	for cmd != nil {
+		msg, ok := cmd().(refreshResultMsg)
+		if !ok {
+			t.Fatalf("unexpected message %T", msg)
+		}
+		_, cmd = m.Update(msg)
+		if msg.done {
+			return partials, msg
+		}
+		partials++
+	}
+	t.Fatalf("refresh stopped after %d partial result(s) without a final message", partials)
+	return partials, final
+}
+
+func TestPollIntervalAdaptsToRunProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	now := time.Now()
+	m := New(Config{Client: stubGitHubClient{}, PollInterval: 10 * time.Second})
+	m.absorbRuns([]githubclient.WorkflowRun{
+		// Earlier CI runs took 20 minutes.
+		{ID: 1, RepoFullName: "example/api", WorkflowName: "CI", Status: githubclient.RunStatusSuccess,
+			StartedAt: now.Add(-2 * time.Hour), LastUpdatedAt: now.Add(-100 * time.Minute)},
+		{ID: 2, RepoFullName: "example/api", WorkflowName: "CI", Status: githubclient.RunStatusFailed,
+			StartedAt: now.Add(-time.Hour), LastUpdatedAt: now.Add(-40 * time.Minute)},
+	}, githuburl.Parsed{})
+
+	cases := []struct {
+		name string
+		run  githubclient.WorkflowRun
+		want time.Duration
+	}{
+		{"just started", githubclient.WorkflowRun{StartedAt: now.Add(-30 * time.Second)}, 5 * time.Second},
+		{"early in a long run", githubclient.WorkflowRun{StartedAt: now.Add(-5 * time.Minute)}, 30 * time.Second},
+		{"close to usual duration", githubclient.WorkflowRun{StartedAt: now.Add(-18 * time.Minute)}, 5 * time.Second},
+		{"midway", githubclient.WorkflowRun{StartedAt: now.Add(-12 * time.Minute)}, 10 * time.Second},
+		{"queued briefly", githubclient.WorkflowRun{CreatedAt: now.Add(-time.Minute), StatusDetail: "queued"}, 10 * time.Second},
+		{"queued for ages", githubclient.WorkflowRun{CreatedAt: now.Add(-time.Hour), StatusDetail: "queued"}, time.Minute},
+	}
+	for _, tc := range cases {
+		run := tc.run
+		run.ID = 99
+		run.RepoFullName = "example/api"
+		run.WorkflowName = "CI"
+		run.Status = githubclient.RunStatusPending
+		if got := m.pollIntervalFor(&watch.TrackedRun{Run: run}, now); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestRefreshSkipsCompletedRunsUnlessForced(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var refreshed []int64
+	m := New(Config{Client: countingClient{mu: &sync.Mutex{}, refreshed: &refreshed}})
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, RepoFullName: "example/api", Status: githubclient.RunStatusSuccess},
+		{ID: 2, RepoFullName: "example/api", Status: githubclient.RunStatusPending},
+	}, githuburl.Parsed{})
+
+	drainRefresh(t, m, m.refreshCmd(true, false))
+	if len(refreshed) != 1 || refreshed[0] != 2 {
+		t.Fatalf("expected only the pending run to be polled, got %v", refreshed)
+	}
+
+	refreshed = nil
+	drainRefresh(t, m, m.refreshCmd(false, true))
+	if len(refreshed) != 2 {
+		t.Fatalf("expected a forced refresh to poll every run, got %v", refreshed)
+	}
+}
+
+func TestRefreshStreamsPartialResultsWithBoundedConcurrency(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var inFlight, peak int
+	m := New(Config{
+		Client:         slowClient{mu: &sync.Mutex{}, inFlight: &inFlight, peak: &peak},
+		Concurrency:    2,
+		RequestTimeout: 50 * time.Millisecond,
+	})
+	var runs []githubclient.WorkflowRun
+	for id := int64(1); id <= 6; id++ {
+		runs = append(runs, githubclient.WorkflowRun{ID: id, RepoFullName: "example/api", Status: githubclient.RunStatusPending})
+	}
+	m.absorbRuns(runs, githuburl.Parsed{})
+
+	partials, final := drainRefresh(t, m, m.refreshCmd(true, false))
+	if partials != 5 {
+		t.Fatalf("expected 5 partial results, got %d", partials)
+	}
+	if final.Err == nil || !strings.Contains(final.Err.Error(), "example/api #1") {
+		t.Fatalf("expected the timed-out run to be reported, got %v", final.Err)
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+	if m.refreshing {
+		t.Fatal("expected refreshing to clear after the final message")
+	}
+	if got := m.tracker.Get(6).Run.Status; got != githubclient.RunStatusSuccess {
+		t.Fatalf("expected run 6 to be refreshed, got %s", got)
+	}
+}
+
+func TestManualRefreshKeepsAutoRefreshGuard(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var refreshed []int64
+	m := New(Config{Client: countingClient{mu: &sync.Mutex{}, refreshed: &refreshed}})
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, RepoFullName: "example/api", Status: githubclient.RunStatusPending},
+	}, githuburl.Parsed{})
+
+	auto := m.refreshCmd(true, false)
+	if auto == nil || !m.refreshing {
+		t.Fatal("expected the scheduled refresh to start")
+	}
+	// The user presses r while the scheduled batch is still in flight.
+	drainRefresh(t, m, m.refreshCmd(false, true))
+	if !m.refreshing {
+		t.Fatal("expected the manual refresh not to clear the scheduled batch's guard")
+	}
+	if m.refreshCmd(true, false) != nil {
+		t.Fatal("expected no overlapping scheduled refresh")
+	}
+
+	drainRefresh(t, m, auto)
+	if m.refreshing {
+		t.Fatal("expected refreshing to clear once the scheduled batch finished")
+	}
+}
+
+func TestGraphQLFallbackUsesThePool(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var inFlight, peak, lookups int
+	m := New(Config{
+		Client:         noGraphQLClient{slowClient: slowClient{mu: &sync.Mutex{}, inFlight: &inFlight, peak: &peak}, lookups: &lookups},
+		GraphQL:        true,
+		Concurrency:    2,
+		RequestTimeout: 50 * time.Millisecond,
+	})
+	var runs []githubclient.WorkflowRun
+	for id := int64(1); id <= 6; id++ {
+		runs = append(runs, githubclient.WorkflowRun{ID: id, NodeID: fmt.Sprintf("WFR_%d", id), RepoFullName: "example/api", Status: githubclient.RunStatusPending})
+	}
+	m.absorbRuns(runs, githuburl.Parsed{})
+
+	partials, final := drainRefresh(t, m, m.refreshCmd(true, false))
+	if partials != 5 {
+		t.Fatalf("expected 5 partial results from the REST fallback, got %d", partials)
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+	if final.Err == nil || !strings.Contains(final.Err.Error(), "GraphQL unavailable") {
+		t.Fatalf("expected the GraphQL failure to be reported, got %v", final.Err)
+	}
+
+	_, final = drainRefresh(t, m, m.refreshCmd(false, true))
+	if lookups != 1 {
+		t.Fatalf("expected GraphQL to be skipped after it failed, got %d lookups", lookups)
+	}
+	if final.Err != nil && strings.Contains(final.Err.Error(), "GraphQL") {
+		t.Fatalf("expected the failure to be reported once, got %v", final.Err)
+	}
+}
+
+// branchClient serves a branch whose head moves to a new commit with a new
+// run after the first lookup.
+type branchClient struct {
+	stubGitHubClient
+	lookups *int
+}
+
+func (c branchClient) RunsByBranch(_ context.Context, _, _, branch string) ([]githubclient.WorkflowRun, error) {
+	*c.lookups++
+	runs := []githubclient.WorkflowRun{{ID: 1, RepoFullName: "example/api", Target: "branch " + branch, Status: githubclient.RunStatusSuccess}}
+	if *c.lookups > 1 {
+		runs = []githubclient.WorkflowRun{{ID: 2, RepoFullName: "example/api", Target: "branch " + branch, Status: githubclient.RunStatusPending}}
+	}
+	return runs, nil
+}
+
+func TestBranchSourceFollowsNewPushes(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var lookups int
+	m := New(Config{Client: branchClient{lookups: &lookups}})
+	source, err := githuburl.Parse("example/api@main")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	msg := fetchRunsCmd(m.client, source)()
+	m.Update(msg)
+
+	drainRefresh(t, m, m.refreshCmd(true, false))
+	run := m.tracker.Get(2)
+	if run == nil {
+		t.Fatal("expected the run from the new push to be tracked")
+	}
+	if run.Source.Kind != githuburl.KindBranch || run.Source.Branch != "main" {
+		t.Fatalf("expected the new run to keep the branch source, got %#v", run.Source)
+	}
+}
+
+func TestTruncationNoticeKeepsRefreshErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}})
+	source := githuburl.Parsed{Kind: githuburl.KindCommit, Owner: "example", Repo: "api", SHA: "abcdef1"}
+	runs := []githubclient.WorkflowRun{{ID: 1, RepoFullName: "example/api", Status: githubclient.RunStatusPending, Truncated: true}}
+	m.absorbRuns(runs, source)
+	if !strings.Contains(m.status.text, "truncated at the page limit") {
+		t.Fatalf("expected the truncation notice when the commit is added, got %q", m.status.text)
+	}
+
+	m.Update(refreshResultMsg{
+		Err:        errors.New("example/api #2: boom"),
+		SourceRuns: map[githuburl.Parsed][]githubclient.WorkflowRun{source: runs},
+	})
+	if m.status.kind != statusError || !strings.Contains(m.status.text, "boom") {
+		t.Fatalf("expected the refresh error to stay visible, got %q", m.status.text)
+	}
+}
+
+func TestFeedFilter(t *testing.T) {
+	got := feedFilter(`workflow:"Deploy prod" branch:main actor:octocat event:push is:failure label:x`)
+	want := githubclient.RunFilter{Workflow: "Deploy prod", Branch: "main", Actor: "octocat", Event: "push", Status: "failure"}
+	if got != want {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+// feedClient serves a repository feed that gains a run after the first poll,
+// and records how far back each poll asked it to read.
+type feedClient struct {
+	stubGitHubClient
+	polls *int
+	since *[]int64
+}
+
+func (c feedClient) RunsByWorkflow(ctx context.Context, owner, repo, _ string) ([]githubclient.WorkflowRun, error) {
+	return c.RecentRuns(ctx, owner, repo, githubclient.RunFilter{})
+}
+
+func (c feedClient) RecentRuns(_ context.Context, _, _ string, filter githubclient.RunFilter) ([]githubclient.WorkflowRun, error) {
+	*c.polls++
+	*c.since = append(*c.since, filter.Since)
+	runs := []githubclient.WorkflowRun{
+		{ID: 5, RepoFullName: "example/api", Status: githubclient.RunStatusSuccess},
+		{ID: 4, RepoFullName: "example/api", Status: githubclient.RunStatusPending},
+		{ID: 3, RepoFullName: "example/api", Status: githubclient.RunStatusSuccess},
+	}
+	if *c.polls > 1 {
+		runs = append([]githubclient.WorkflowRun{{ID: 6, RepoFullName: "example/api", Status: githubclient.RunStatusPending}}, runs...)
+	}
+	return runs, nil
+}
+
+func TestFeedsTrackNewRuns(t *testing.T) {
+	for _, raw := range []string{
+		"https://github.com/example/api/actions?query=branch%3Amain",
+		"https://github.com/example/api/actions/workflows/deploy.yml",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", tmpDir)
+
+			var (
+				polls int
+				since []int64
+			)
+			m := New(Config{Client: feedClient{polls: &polls, since: &since}})
+			source, err := githuburl.Parse(raw)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			m.Update(fetchRunsCmd(m.client, source)())
+			if got := m.tracker.IDs(false); len(got) != 2 {
+				t.Fatalf("expected the newest and the in-progress run, got %v", got)
+			}
+
+			drainRefresh(t, m, m.refreshCmd(true, false))
+			if run := m.tracker.Get(6); run == nil || run.Source.Kind != source.Kind {
+				t.Fatal("expected the new run to be added with the feed source")
+			}
+			if m.tracker.Get(3) != nil {
+				t.Fatal("expected older finished runs to stay out of the list")
+			}
+			if source.Kind == githuburl.KindActions && (len(since) != 2 || since[0] != 0 || since[1] != 5) {
+				t.Fatalf("expected the refresh to read back to run 5, got %v", since)
+			}
+		})
+	}
+}
+
+// runByIDClient answers run lookups with a pending run carrying the ID.
+type runByIDClient struct {
+	stubGitHubClient
+}
+
+func (runByIDClient) WorkflowRunByID(_ context.Context, _, _ string, runID int64) (githubclient.WorkflowRun, error) {
+	return githubclient.WorkflowRun{ID: runID, RepoFullName: "example/api", Status: githubclient.RunStatusPending}, nil
+}
+
+func TestJobLinksPreselectJob(t *testing.T) {
+	for _, raw := range []string{
+		"https://github.com/example/api/actions/runs/42/job/10",
+		"https://github.com/example/api/runs/10",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", tmpDir)
+
+			m := New(Config{Client: runByIDClient{}})
+			m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+			m.absorbRuns([]githubclient.WorkflowRun{{ID: 7, RepoFullName: "example/api", Status: githubclient.RunStatusPending}}, githuburl.Parsed{})
+
+			source, err := githuburl.Parse(raw)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			_, cmd := m.Update(fetchRunsCmd(m.client, source)())
+			if run := m.selectedRun(); run == nil || run.Run.ID != 42 {
+				t.Fatalf("expected run 42 to be selected, got %#v", run)
+			}
+			if !m.detail.open || cmd == nil {
+				t.Fatal("expected the detail pane to open and load jobs")
+			}
+			m.Update(cmd())
+			// Job 11 failed and would be picked by default.
+			if job := m.selectedJob(); job == nil || job.ID != 10 {
+				t.Fatalf("expected job 10 to be selected, got %#v", job)
+			}
+		})
+	}
+}
+
+func TestInputPreviewShowsParsedShortForm(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+	githuburl.SetDefaultRepo("", "example", "api")
+	t.Cleanup(func() { githuburl.SetDefaultRepo("", "", "") })
+
+	m := New(Config{Client: stubGitHubClient{}})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m.setFocus(focusInput)
+
+	m.input.SetValue("#42")
+	if preview := renderHelpText(m); !strings.Contains(preview, "example/api PR #42") {
+		t.Fatalf("expected PR preview, got %q", preview)
+	}
+
+	m.input.SetValue("example/api@not a ref")
+	if preview := renderHelpText(m); !strings.Contains(preview, "unsupported host") {
+		t.Fatalf("expected parse error preview, got %q", preview)
+	}
+
+	m.input.SetValue("")
+	if preview := renderHelpText(m); !strings.Contains(preview, "[tab] focus") {
+		t.Fatalf("expected help text for empty input, got %q", preview)
+	}
+}
+
+// prClient returns one pending run per PR and fails for PR 404.
+type prClient struct {
+	stubGitHubClient
+}
+
+func (prClient) RunsByPullRequest(_ context.Context, owner, repo string, number int) ([]githubclient.WorkflowRun, error) {
+	if number == 404 {
+		return nil, errors.New("not found")
+	}
+	return []githubclient.WorkflowRun{{
+		ID:           int64(number),
+		RepoFullName: owner + "/" + repo,
+		Status:       githubclient.RunStatusPending,
+	}}, nil
+}
+
+func TestPasteSubmitsEveryReference(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: prClient{}})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+
+	githuburl.SetDefaultRepo("github.com", "example", "api")
+	t.Cleanup(func() { githuburl.SetDefaultRepo("", "", "") })
+
+	paste := "can you look at these 2 (#3 is done):\n" +
+		"https://github.com/example/api/pull/1\n" +
+		"<https://github.com/example/api/pull/2>,\n" +
+		"example/api#1 https://github.com/example/api/pull/404\n" +
+		"https://github.com/example/api/issues/3\n"
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(paste), Paste: true})
+	if cmd == nil {
+		t.Fatal("expected the paste to start fetching")
+	}
+	m.Update(cmd())
+
+	if runs := m.tracker.VisibleRuns(false); len(runs) != 2 {
+		t.Fatalf("expected runs for PRs 1 and 2, got %d", len(runs))
+	}
+	if m.status.kind != statusError {
+		t.Fatalf("expected an error summary, got %q", m.status.text)
+	}
+	for _, want := range []string{"Watching 2/4 references", "example/api PR #404: not found", "issues/3: unsupported GitHub URL path"} {
+		if !strings.Contains(m.status.text, want) {
+			t.Fatalf("expected status to contain %q, got %q", want, m.status.text)
+		}
+	}
+	if m.input.Value() != "" {
+		t.Fatalf("expected the input to stay empty, got %q", m.input.Value())
+	}
+}
+
+func TestSubmitSplitsInputOnWhitespace(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: prClient{}})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m.setFocus(focusInput)
+	m.input.SetValue("example/api#1 example/api#2")
+
+	_, cmd := m.submitURL()
+	m.Update(cmd())
+
+	if runs := m.tracker.VisibleRuns(false); len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+	if m.status.text != "Watching all 2 references" {
+		t.Fatalf("unexpected status %q", m.status.text)
+	}
+}
+
+// checkoutClient has an open PR #7 for the "feature" branch only.
+type checkoutClient struct {
+	stubGitHubClient
+}
+
+func (checkoutClient) OpenPullRequestForBranch(_ context.Context, _, _, branch string) (int, error) {
+	if branch == "feature" {
+		return 7, nil
+	}
+	return 0, nil
+}
+
+func (checkoutClient) RunsByPullRequest(_ context.Context, _, _ string, number int) ([]githubclient.WorkflowRun, error) {
+	return []githubclient.WorkflowRun{{ID: int64(number), RepoFullName: "example/api", Status: githubclient.RunStatusPending}}, nil
+}
+
+func (checkoutClient) RunsByCommit(_ context.Context, _, _, sha string) ([]githubclient.WorkflowRun, error) {
+	return []githubclient.WorkflowRun{{ID: 99, RepoFullName: "example/api", HeadSHA: sha, Status: githubclient.RunStatusPending}}, nil
+}
+
+func TestWatchCheckoutPrefersOpenPullRequest(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	checkout := t.TempDir()
+	gitDir := filepath.Join(checkout, ".git")
+	for name, content := range map[string]string{
+		"config":              "[remote \"origin\"]\n\turl = git@github.com:example/api.git\n",
+		"HEAD":                "ref: refs/heads/feature\n",
+		"refs/heads/feature":  "1111111111111111111111111111111111111111\n",
+		"refs/heads/unpushed": "2222222222222222222222222222222222222222\n",
+	} {
+		path := filepath.Join(gitDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := New(Config{Client: checkoutClient{}, CheckoutDir: checkout})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	msg, ok := cmd().(fetchResultMsg)
+	if !ok || msg.Source.Kind != githuburl.KindPullRequest || msg.Source.PRNumber != 7 {
+		t.Fatalf("expected the branch's open PR, got %#v", msg)
+	}
+	m.Update(msg)
+	if run := m.selectedRun(); run == nil || run.Run.ID != 7 {
+		t.Fatalf("expected the PR's run to be tracked, got %#v", run)
+	}
+
+	// Without an open PR, the checked-out commit is watched.
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/unpushed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	msg, ok = cmd().(fetchResultMsg)
+	if !ok || msg.Source.Kind != githuburl.KindCommit || msg.Source.SHA != "2222222222222222222222222222222222222222" {
+		t.Fatalf("expected the HEAD commit, got %#v", msg)
+	}
+}
+
+// discoveryClient serves search results from authored and requested, and
+// reports PRs in closed as closed and PRs in idle as having no runs yet. A
+// non-nil searchErr fails every search.
+type discoveryClient struct {
+	prClient
+	mu        *sync.Mutex
+	authored  *[]int
+	requested *[]int
+	closed    map[int]bool
+	idle      map[int]bool
+	searchErr *error
+}
+
+func (c discoveryClient) RunsByPullRequest(ctx context.Context, owner, repo string, number int) ([]githubclient.WorkflowRun, error) {
+	c.mu.Lock()
+	idle := c.idle[number]
+	c.mu.Unlock()
+	if idle {
+		return nil, nil
+	}
+	return c.prClient.RunsByPullRequest(ctx, owner, repo, number)
+}
+
+func (c discoveryClient) SearchPullRequests(_ context.Context, query string) ([]githubclient.PullRequestRef, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.searchErr != nil && *c.searchErr != nil {
+		return nil, false, *c.searchErr
+	}
+	numbers := *c.authored
+	if strings.Contains(query, "review-requested:@me") {
+		numbers = *c.requested
+	}
+	var refs []githubclient.PullRequestRef
+	for _, number := range numbers {
+		refs = append(refs, githubclient.PullRequestRef{Owner: "example", Repo: "api", Number: number})
+	}
+	return refs, false, nil
+}
+
+func (c discoveryClient) PullRequestOpen(_ context.Context, _, _ string, number int) (bool, error) {
+	return !c.closed[number], nil
+}
+
+func TestDiscoveryWatchesOpenPullRequests(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	authored, requested := []int{1}, []int{2}
+	client := discoveryClient{mu: &sync.Mutex{}, authored: &authored, requested: &requested, closed: map[int]bool{}}
+	m := New(Config{Client: client, DiscoverPRs: true})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+
+	discover := func() {
+		t.Helper()
+		msg, ok := m.discoverCmd()().(discoverResultMsg)
+		if !ok || msg.Err != nil {
+			t.Fatalf("unexpected discovery result %#v", msg)
+		}
+		m.Update(msg)
+	}
+
+	discover()
+	if ids := m.tracker.IDs(false); len(ids) != 2 {
+		t.Fatalf("expected runs for PRs 1 and 2, got %v", ids)
+	}
+	if run := m.tracker.Get(2); run == nil || run.Source.Kind != githuburl.KindPullRequest || run.Source.PRNumber != 2 {
+		t.Fatalf("expected run 2 to come from PR #2, got %#v", run)
+	}
+
+	// PR 1 merges and the review on PR 2 is submitted: only PR 1 closed.
+	client.mu.Lock()
+	authored, requested = nil, nil
+	client.mu.Unlock()
+	client.closed[1] = true
+	discover()
+
+	if ids := m.tracker.IDs(false); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("expected only PR 2's run to stay active, got %v", ids)
+	}
+	if m.tracker.LenArchived() != 1 {
+		t.Fatalf("expected PR 1's run to be archived, got %d archived", m.tracker.LenArchived())
+	}
+	if !strings.Contains(m.status.text, "archived 1 run(s) of closed PRs") {
+		t.Fatalf("unexpected status %q", m.status.text)
+	}
+}
+
+func TestDiscoverySearchLimitKeepsPolling(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	var searchErr error = &githubclient.RateLimitError{RetryAt: time.Now().Add(time.Hour)}
+	authored, requested := []int(nil), []int(nil)
+	client := discoveryClient{mu: &sync.Mutex{}, authored: &authored, requested: &requested, closed: map[int]bool{}, searchErr: &searchErr}
+	m := New(Config{Client: client, DiscoverPRs: true})
+
+	msg, ok := m.discoverCmd()().(discoverResultMsg)
+	if !ok || !errors.Is(msg.Err, githubclient.ErrRateLimited) {
+		t.Fatalf("expected a rate limited search, got %#v", msg)
+	}
+	m.Update(msg)
+	if m.rateLimited() {
+		t.Fatal("expected a search rate limit not to pause polling")
+	}
+	if !strings.Contains(m.status.text, "PR discovery") {
+		t.Fatalf("unexpected status %q", m.status.text)
+	}
+}
+
+func TestDiscoveryAfterRestart(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	authored, requested := []int{1, 2, 3}, []int(nil)
+	client := discoveryClient{mu: &sync.Mutex{}, authored: &authored, requested: &requested, closed: map[int]bool{}, idle: map[int]bool{3: true}}
+	discover := func(m *Model) {
+		t.Helper()
+		msg, ok := m.discoverCmd()().(discoverResultMsg)
+		if !ok || msg.Err != nil {
+			t.Fatalf("unexpected discovery result %#v", msg)
+		}
+		m.Update(msg)
+	}
+
+	m := New(Config{Client: client, DiscoverPRs: true})
+	discover(m)
+	if _, ok := m.discovered["github.com/example/api/pull/3"]; ok {
+		t.Fatal("expected PR 3 without runs to be retried next round")
+	}
+	m.selectRun(2)
+	m.archiveSelected()
+
+	// While ghwatch is off, PR 1 merges and PR 3 starts its first run.
+	client.mu.Lock()
+	authored = []int{2, 3}
+	client.closed[1] = true
+	delete(client.idle, 3)
+	client.mu.Unlock()
+
+	m = New(Config{Client: client, DiscoverPRs: true})
+	discover(m)
+	if ids := m.tracker.IDs(false); len(ids) != 1 || ids[0] != 3 {
+		t.Fatalf("expected only PR 3's run to be active, got %v", ids)
+	}
+	if ids := m.tracker.IDs(true); len(ids) != 2 {
+		t.Fatalf("expected the runs of PRs 1 and 2 to stay archived, got %v", ids)
+	}
+}
+
+// pushedPRClient lists one run for the PR's current head commit.
+type pushedPRClient struct {
+	stubGitHubClient
+	head *string
+}
+
+func (c pushedPRClient) RunsByPullRequest(_ context.Context, _, _ string, number int) ([]githubclient.WorkflowRun, error) {
+	id := int64(100)
+	if *c.head == "bbb" {
+		id = 101
+	}
+	return []githubclient.WorkflowRun{{ID: id, RepoFullName: "example/api", HeadSHA: *c.head, Target: fmt.Sprintf("PR #%d", number), Status: githubclient.RunStatusPending}}, nil
+}
+
+func TestForcePushSupersedesOldRuns(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		t.Run(fmt.Sprintf("archive=%v", archive), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", tmpDir)
+
+			head := "aaa"
+			client := pushedPRClient{head: &head}
+			m := New(Config{Client: client, ArchiveSuperseded: archive})
+			m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+
+			pr, err := githuburl.Parse("https://github.com/example/api/pull/7")
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			m.Update(fetchRunsCmd(client, pr)())
+
+			head = "bbb"
+			source := m.tracker.Get(100).Source
+			if source.SHA != "aaa" {
+				t.Fatalf("expected the source to record the PR head, got %q", source.SHA)
+			}
+			m.Update(refreshResultMsg{SourceRuns: map[githuburl.Parsed][]githubclient.WorkflowRun{
+				source: mustFetch(t, client, source),
+			}, done: true})
+
+			old := m.tracker.Get(100)
+			if old == nil || !old.Superseded || m.tracker.Get(101).Superseded {
+				t.Fatalf("expected run 100 to be superseded by run 101")
+			}
+			if ids := m.tracker.IDs(false); archive != (len(ids) == 1) {
+				t.Fatalf("archive=%v but active runs are %v", archive, ids)
+			}
+			if !archive && !strings.Contains(tableRowData(old)[3], "PR #7 (superseded)") {
+				t.Fatalf("expected the superseded run to be labelled, got %v", tableRowData(old))
+			}
+		})
+	}
+}
+
+func mustFetch(t *testing.T, client githubAPI, source githuburl.Parsed) []githubclient.WorkflowRun {
+	t.Helper()
+	runs, err := fetchSourceRuns(context.Background(), client, source, 0)
+	if err != nil {
+		t.Fatalf("fetchSourceRuns returned error: %v", err)
+	}
+	return runs
+}
+
+func TestGroupedViewSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, BellEnabled: true})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+	m = updated.(*Model)
+
+	pr := githuburl.Parsed{Kind: githuburl.KindPullRequest, Host: githuburl.DefaultHost, Owner: "example", Repo: "api", PRNumber: 12}
+	prRun := func(id int64, name string, status githubclient.RunStatus) githubclient.WorkflowRun {
+		return githubclient.WorkflowRun{
+			ID:           id,
+			Name:         name,
+			WorkflowName: "CI",
+			RepoFullName: "example/api",
+			Target:       "PR #12",
+			PRURL:        "https://github.com/example/api/pull/12",
+			Status:       status,
+		}
+	}
+	m.absorbRuns([]githubclient.WorkflowRun{
+		prRun(1, "unit", githubclient.RunStatusSuccess),
+		prRun(2, "lint", githubclient.RunStatusFailed),
+		prRun(3, "e2e", githubclient.RunStatusPending),
+	}, pr)
+	m.absorbRuns([]githubclient.WorkflowRun{{
+		ID:           4,
+		Name:         "deploy",
+		WorkflowName: "Deploy",
+		RepoFullName: "example/web",
+		Target:       "run 4",
+		Status:       githubclient.RunStatusSuccess,
+	}}, githuburl.Parsed{Kind: githuburl.KindWorkflowRun, Owner: "example", Repo: "web", RunID: 4})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	rows := m.listRows()
+	if len(rows) != 2 || rows[0].header() || !rows[1].header() {
+		t.Fatalf("expected the single run and a collapsed PR group, got %d rows", len(rows))
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if rows := m.listRows(); len(rows) != 5 {
+		t.Fatalf("expected the expanded group to list its runs, got %d rows", len(rows))
+	}
+
+	snaps.MatchSnapshot(t, m.View())
+}
+
+func TestGroupAggregatesAndActions(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", tmpDir)
+
+	m := New(Config{Client: stubGitHubClient{}, GroupBySource: true})
+	m.Update(tea.WindowSizeMsg{Width: 90, Height: 24})
+
+	commit := githuburl.Parsed{Kind: githuburl.KindCommit, Host: githuburl.DefaultHost, Owner: "example", Repo: "api", SHA: "abc1234"}
+	m.absorbRuns([]githubclient.WorkflowRun{
+		{ID: 1, RepoFullName: "example/api", Status: githubclient.RunStatusSuccess, TargetURL: "https://github.com/example/api/commit/abc1234"},
+		{ID: 2, RepoFullName: "example/api", Status: githubclient.RunStatusSkipped},
+	}, commit)
+
+	row, ok := m.selectedRow()
+	if !ok || !row.header() {
+		t.Fatal("expected the group header to be selected")
+	}
+	if status := row.group.status(); status != githubclient.RunStatusSuccess {
+		t.Fatalf("expected an all-green group to pass, got %s", status)
+	}
+	row.group.runs[1].Run.Status = githubclient.RunStatusPending
+	if status := row.group.status(); status != githubclient.RunStatusPending {
+		t.Fatalf("expected an unfinished group to be pending, got %s", status)
+	}
+	row.group.runs[0].Run.Status = githubclient.RunStatusFailed
+	if status := row.group.status(); status != githubclient.RunStatusFailed {
+		t.Fatalf("expected any failure to fail the group, got %s", status)
+	}
+	if url := row.group.url(); url != "https://github.com/example/api/commit/abc1234" {
+		t.Fatalf("expected the group to open its commit, got %q", url)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.tracker.LenActive() != 0 || m.tracker.LenArchived() != 2 {
+		t.Fatalf("expected the whole group to be archived, got %d active", m.tracker.LenActive())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.tracker.LenActive() != 2 || m.showArchived {
+		t.Fatalf("expected the whole group to be restored, got %d active", m.tracker.LenActive())
+	}
+
+	// Selecting a run inside a collapsed group expands it.
+	if !m.selectRun(2) || !m.expanded[commit.Key()] || m.selectedRun().Run.ID != 2 {
+		t.Fatal("expected selectRun to expand the group and select the run")
+	}
+}
@@ -0,0 +1,87 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// Annotation is a file/line diagnostic attached to a check run, e.g. a lint
+// error or a failed test assertion.
+type Annotation struct {
+	CheckRunName string
+	Path         string
+	StartLine    int
+	EndLine      int
+	Level        string
+	Title        string
+	Message      string
+}
+
+// Location formats the annotation as path:line for editors and clipboards.
+func (a Annotation) Location() string {
+	if a.StartLine <= 0 {
+		return a.Path
+	}
+	return fmt.Sprintf("%s:%d", a.Path, a.StartLine)
+}
+
+// AnnotationsForCheckSuite collects the annotations of every check run in a
+// check suite. Workflow runs map 1:1 to a check suite (see
+// WorkflowRun.CheckSuiteID), so this is how the failures of a run are listed.
+func (c *Client) AnnotationsForCheckSuite(ctx context.Context, owner, repo string, suiteID int64) ([]Annotation, error) {
+	path := fmt.Sprintf("/repos/%s/%s/check-suites/%d/check-runs", owner, repo, suiteID)
+	query := map[string]string{"per_page": runsPerPage}
+	checkRuns, _, err := listAll(ctx, c, path, query, func(p checkRunsResponse) []checkRunPayload {
+		return p.CheckRuns
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Annotation
+	for _, checkRun := range checkRuns {
+		if checkRun.Output.AnnotationsCount == 0 {
+			continue
+		}
+		path := fmt.Sprintf("/repos/%s/%s/check-runs/%d/annotations", owner, repo, checkRun.ID)
+		annotations, _, err := listAll(ctx, c, path, query, func(p []annotationPayload) []annotationPayload {
+			return p
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range annotations {
+			out = append(out, Annotation{
+				CheckRunName: checkRun.Name,
+				Path:         a.Path,
+				StartLine:    a.StartLine,
+				EndLine:      a.EndLine,
+				Level:        a.AnnotationLevel,
+				Title:        a.Title,
+				Message:      a.Message,
+			})
+		}
+	}
+	return out, nil
+}
+
+type checkRunsResponse struct {
+	CheckRuns []checkRunPayload `json:"check_runs"`
+}
+
+type checkRunPayload struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Output struct {
+		AnnotationsCount int `json:"annotations_count"`
+	} `json:"output"`
+}
+
+type annotationPayload struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
@@ -0,0 +1,92 @@
+package githubclient
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries bounds the conditional-request cache so long sessions that
+// watch many runs don't grow memory (or the persisted cache file) forever.
+const maxCacheEntries = 256
+
+// CacheEntry is a cached GET response keyed by its absolute URL. Entries carry
+// the validators GitHub handed out so the next request can be conditional; a
+// 304 reply is served from Body and does not count against the rate limit.
+type CacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Link         string    `json:"link,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]CacheEntry)}
+}
+
+func (c *responseCache) get(url string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	return entry, ok
+}
+
+func (c *responseCache) put(entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[entry.URL] = entry
+	c.evictLocked()
+}
+
+func (c *responseCache) snapshot() []CacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]CacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StoredAt.After(out[j].StoredAt) })
+	return out
+}
+
+// evictLocked drops the oldest entries once the cache grows past its bound.
+func (c *responseCache) evictLocked() {
+	if len(c.entries) <= maxCacheEntries {
+		return
+	}
+	oldest := make([]CacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		oldest = append(oldest, entry)
+	}
+	sort.Slice(oldest, func(i, j int) bool { return oldest[i].StoredAt.Before(oldest[j].StoredAt) })
+	for _, entry := range oldest[:len(oldest)-maxCacheEntries] {
+		delete(c.entries, entry.URL)
+	}
+}
+
+// ExportCache returns the cached responses, newest first, for persistence.
+func (c *Client) ExportCache() []CacheEntry {
+	return c.cache.snapshot()
+}
+
+// ImportCache seeds the conditional-request cache, typically with entries
+// saved by a previous session. Entries for other API hosts are ignored.
+func (c *Client) ImportCache(entries []CacheEntry) {
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.URL, c.baseURL) {
+			continue
+		}
+		if entry.ETag == "" && entry.LastModified == "" {
+			continue
+		}
+		c.cache.put(entry)
+	}
+}
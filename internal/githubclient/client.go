@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,15 +19,33 @@ import (
 type RunStatus string
 
 const (
-	RunStatusPending RunStatus = "pending"
-	RunStatusSuccess RunStatus = "success"
-	RunStatusFailed  RunStatus = "failed"
+	RunStatusPending        RunStatus = "pending"
+	RunStatusWaiting        RunStatus = "waiting" // waiting for a deployment approval
+	RunStatusSuccess        RunStatus = "success"
+	RunStatusFailed         RunStatus = "failed"
+	RunStatusCancelled      RunStatus = "cancelled"
+	RunStatusSkipped        RunStatus = "skipped"
+	RunStatusNeutral        RunStatus = "neutral"
+	RunStatusActionRequired RunStatus = "action_required"
 )
 
+// Completed reports whether the status is final, i.e. GitHub will not move
+// the run any further without a rerun.
+func (s RunStatus) Completed() bool {
+	switch s {
+	case RunStatusPending, RunStatusWaiting, "":
+		return false
+	default:
+		return true
+	}
+}
+
 // WorkflowRun contains the normalized subset of GitHub workflow run data that
 // the watcher needs to render UI and detect state changes.
 type WorkflowRun struct {
 	ID            int64
+	NodeID        string
+	Host          string
 	Name          string
 	WorkflowName  string
 	RepoFullName  string
@@ -39,14 +59,37 @@ type WorkflowRun struct {
 	Event         string
 	PRNumber      int
 	PRURL         string
+	CheckSuiteID  int64
+	Attempt       int
+	CreatedAt     time.Time
+	StartedAt     time.Time
 	LastUpdatedAt time.Time
+	// Truncated is set when the run came from a listing that hit the page cap
+	// before GitHub ran out of results.
+	Truncated bool
 }
 
+// DefaultMaxPages bounds how many pages a single listing will follow.
+const DefaultMaxPages = 10
+
+const runsPerPage = "100"
+
 // Client talks to the GitHub REST API.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	host       string
 	token      string
+	maxPages   int
+	cache      *responseCache
+	allowWrite bool
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+
+	rateMu       sync.Mutex
+	rate         RateLimit
+	blockedUntil map[string]time.Time
 }
 
 // New creates a GitHub client. If token is empty, well-known environment
@@ -59,16 +102,100 @@ func New(token string) *Client {
 			os.Getenv("GH_PAT"),
 		)
 	}
+	return newClient(defaultHost, token)
+}
+
+// NewEnterprise creates a client for a GitHub Enterprise Server host, talking
+// to its https://<host>/api/v3 endpoint. If token is empty, the host's own
+// variable (see EnterpriseTokenEnv) is checked, then the shared
+// GH_ENTERPRISE_TOKEN and GITHUB_ENTERPRISE_TOKEN.
+func NewEnterprise(host, token string) *Client {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if token == "" {
+		token = firstNonEmpty(
+			os.Getenv(EnterpriseTokenEnv(host)),
+			os.Getenv("GH_ENTERPRISE_TOKEN"),
+			os.Getenv("GITHUB_ENTERPRISE_TOKEN"),
+		)
+	}
+	return newClient(host, token)
+}
 
+// EnterpriseTokenEnv names the environment variable holding the token for one
+// GitHub Enterprise Server host: GH_ENTERPRISE_TOKEN_ followed by the host
+// upper-cased, with anything but letters and digits replaced by "_"
+// (ghe.example.com -> GH_ENTERPRISE_TOKEN_GHE_EXAMPLE_COM).
+func EnterpriseTokenEnv(host string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(host))
+	return "GH_ENTERPRISE_TOKEN_" + name
+}
+
+func newClient(host, token string) *Client {
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: 20 * time.Second,
+			Timeout:       20 * time.Second,
+			CheckRedirect: dropAuthOnRedirect,
 		},
-		baseURL: "https://api.github.com",
-		token:   token,
+		baseURL:  APIBaseURL(host),
+		host:     host,
+		token:    token,
+		maxPages: DefaultMaxPages,
+		cache:    newResponseCache(),
+
+		maxRetries:     DefaultMaxRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
 	}
 }
 
+const defaultHost = "github.com"
+
+// dropAuthOnRedirect keeps the token from following redirects to other hosts
+// (log downloads bounce to blob storage), including different ports.
+func dropAuthOnRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if req.URL.Host != via[0].URL.Host {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// APIBaseURL maps a web host to its REST API root: api.github.com for
+// github.com and /api/v3 for GitHub Enterprise Server.
+func APIBaseURL(host string) string {
+	if host == "" || host == defaultHost {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+// Host returns the web host (e.g. github.com) this client talks to.
+func (c *Client) Host() string {
+	return c.host
+}
+
+func (c *Client) webURL(format string, args ...any) string {
+	return "https://" + c.host + fmt.Sprintf(format, args...)
+}
+
+// SetMaxPages changes how many pages listing calls follow before giving up and
+// flagging the results as truncated. Values below one reset to the default.
+func (c *Client) SetMaxPages(n int) {
+	if n < 1 {
+		n = DefaultMaxPages
+	}
+	c.maxPages = n
+}
+
 func firstNonEmpty(values ...string) string {
 	for _, v := range values {
 		if strings.TrimSpace(v) != "" {
@@ -97,6 +224,7 @@ func (c *Client) WorkflowRunByID(ctx context.Context, owner, repo string, runID
 		return WorkflowRun{}, err
 	}
 	run := convertRun(payload)
+	run.Host = c.host
 	if run.RepoFullName == "" {
 		run.RepoFullName = fmt.Sprintf("%s/%s", owner, repo)
 	}
@@ -111,18 +239,20 @@ func (c *Client) WorkflowRunByID(ctx context.Context, owner, repo string, runID
 
 // RunsByCommit fetches all runs matching the supplied commit SHA.
 func (c *Client) RunsByCommit(ctx context.Context, owner, repo, sha string) ([]WorkflowRun, error) {
-	query := map[string]string{"per_page": "30", "head_sha": sha}
-	payload, err := c.listRuns(ctx, owner, repo, query)
+	query := map[string]string{"per_page": runsPerPage, "head_sha": sha}
+	payload, truncated, err := c.listRuns(ctx, owner, repo, query)
 	if err != nil {
 		return nil, err
 	}
 
-	commitURL := fmt.Sprintf("https://github.com/%s/%s/commit/%s", owner, repo, sha)
+	commitURL := c.webURL("/%s/%s/commit/%s", owner, repo, sha)
 	return decorateRuns(payload, func(r *WorkflowRun) {
+		r.Host = c.host
 		r.Target = fmt.Sprintf("commit %.7s", sha)
 		if r.TargetURL == "" {
 			r.TargetURL = commitURL
 		}
+		r.Truncated = truncated
 	}), nil
 }
 
@@ -140,7 +270,7 @@ func (c *Client) RunsByPullRequest(ctx context.Context, owner, repo string, numb
 		return nil, err
 	}
 
-	prURL := fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, number)
+	prURL := c.webURL("/%s/%s/pull/%d", owner, repo, number)
 	for i := range runs {
 		runs[i].Target = fmt.Sprintf("PR #%d", number)
 		runs[i].TargetURL = prURL
@@ -151,53 +281,329 @@ func (c *Client) RunsByPullRequest(ctx context.Context, owner, repo string, numb
 	return runs, nil
 }
 
-func (c *Client) listRuns(ctx context.Context, owner, repo string, query map[string]string) ([]workflowRunPayload, error) {
-	path := fmt.Sprintf("/repos/%s/%s/actions/runs", owner, repo)
+// OpenPullRequestForBranch returns the number of the open pull request whose
+// head is branch in the same repository, or 0 if there is none.
+func (c *Client) OpenPullRequestForBranch(ctx context.Context, owner, repo, branch string) (int, error) {
+	query := map[string]string{
+		"head":     fmt.Sprintf("%s:%s", owner, branch),
+		"state":    "open",
+		"per_page": "1",
+	}
+	var payload []pullRequestPayload
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/pulls", owner, repo), query, &payload); err != nil {
+		return 0, err
+	}
+	if len(payload) == 0 {
+		return 0, nil
+	}
+	return payload[0].Number, nil
+}
+
+// SearchPullRequests returns the pull requests matching an issue search
+// query such as "is:pr is:open author:@me", following pages up to the
+// client's page cap. The boolean reports whether the results were cut short.
+func (c *Client) SearchPullRequests(ctx context.Context, query string) ([]PullRequestRef, bool, error) {
+	type searchItem struct {
+		Number        int       `json:"number"`
+		RepositoryURL string    `json:"repository_url"`
+		PullRequest   *struct{} `json:"pull_request"`
+	}
+	type searchResponse struct {
+		Items []searchItem `json:"items"`
+	}
+	items, truncated, err := listAll(ctx, c, "/search/issues", map[string]string{"q": query, "per_page": "100"}, func(p searchResponse) []searchItem {
+		return p.Items
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	refs := make([]PullRequestRef, 0, len(items))
+	for _, item := range items {
+		if item.PullRequest == nil {
+			continue
+		}
+		// repository_url is <api base>/repos/<owner>/<repo>.
+		_, repoPath, ok := strings.Cut(item.RepositoryURL, "/repos/")
+		owner, repo, ok2 := strings.Cut(repoPath, "/")
+		if !ok || !ok2 {
+			continue
+		}
+		refs = append(refs, PullRequestRef{Owner: owner, Repo: repo, Number: item.Number})
+	}
+	return refs, truncated, nil
+}
+
+// PullRequestOpen reports whether a pull request is still open.
+func (c *Client) PullRequestOpen(ctx context.Context, owner, repo string, number int) (bool, error) {
+	var payload pullRequestPayload
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), nil, &payload); err != nil {
+		return false, err
+	}
+	return payload.State == "open", nil
+}
+
+// RunsByBranch resolves the branch's current head commit and returns its
+// workflow runs, so polling a branch follows new pushes.
+func (c *Client) RunsByBranch(ctx context.Context, owner, repo, branch string) ([]WorkflowRun, error) {
+	var payload branchPayload
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/branches/%s", owner, repo, escapeRef(branch)), nil, &payload); err != nil {
+		return nil, err
+	}
+
+	runs, err := c.RunsByCommit(ctx, owner, repo, payload.Commit.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	branchURL := c.webURL("/%s/%s/tree/%s", owner, repo, escapeRef(branch))
+	for i := range runs {
+		runs[i].Target = fmt.Sprintf("branch %s", branch)
+		runs[i].TargetURL = branchURL
+	}
+	return runs, nil
+}
+
+// RunFilter narrows a repository's run listing, mirroring the search box on
+// the Actions page. Workflow matches the workflow name or file name. Since is
+// the newest run ID the caller has already seen; pages are followed back to
+// it (up to the page cap) so runs that scrolled by between polls still show
+// up. Zero reads a single page.
+type RunFilter struct {
+	Workflow string
+	Branch   string
+	Actor    string
+	Event    string
+	Status   string
+	Since    int64
+}
+
+// feedPageSize is how many runs RecentRuns reads per page.
+const feedPageSize = "30"
+
+// RecentRuns lists a repository's most recent workflow runs (newest first)
+// matching filter. A workflow given by file name is listed through that
+// workflow's own endpoint; a workflow name can only be matched here, on the
+// repository's runs.
+func (c *Client) RecentRuns(ctx context.Context, owner, repo string, filter RunFilter) ([]WorkflowRun, error) {
+	query := map[string]string{"per_page": feedPageSize}
+	for key, value := range map[string]string{
+		"branch": filter.Branch,
+		"actor":  filter.Actor,
+		"event":  filter.Event,
+		"status": filter.Status,
+	} {
+		if value != "" {
+			query[key] = value
+		}
+	}
+
+	endpoint := fmt.Sprintf("/repos/%s/%s/actions/runs", owner, repo)
+	byName := filter.Workflow
+	if isWorkflowFile(filter.Workflow) {
+		endpoint = fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/runs", owner, repo, url.PathEscape(filter.Workflow))
+		byName = ""
+	}
+	payload, err := c.listRunsSince(ctx, endpoint, query, filter.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]WorkflowRun, 0, len(payload))
+	for _, item := range payload {
+		// The REST API can't filter by workflow name, so do it here.
+		if byName != "" && !strings.EqualFold(item.Name, byName) &&
+			!strings.EqualFold(path.Base(item.Path), byName) {
+			continue
+		}
+		run := convertRun(item)
+		run.Host = c.host
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// RunsByWorkflow lists the most recent runs of one workflow (one page,
+// newest first). workflow is the file name, e.g. deploy.yml, or its ID.
+func (c *Client) RunsByWorkflow(ctx context.Context, owner, repo, workflow string) ([]WorkflowRun, error) {
 	var payload workflowRunsResponse
-	if err := c.getJSON(ctx, path, query, &payload); err != nil {
+	query := map[string]string{"per_page": feedPageSize}
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/runs", owner, repo, url.PathEscape(workflow)), query, &payload); err != nil {
+		return nil, err
+	}
+	return decorateRuns(payload.WorkflowRuns, func(r *WorkflowRun) {
+		r.Host = c.host
+	}), nil
+}
+
+func isWorkflowFile(workflow string) bool {
+	lower := strings.ToLower(workflow)
+	return strings.HasSuffix(lower, ".yml") || strings.HasSuffix(lower, ".yaml")
+}
+
+// listRunsSince reads a newest-first run listing page by page until a page
+// reaches run ID since, GitHub runs out of pages, or the page cap is hit.
+func (c *Client) listRunsSince(ctx context.Context, path string, query map[string]string, since int64) ([]workflowRunPayload, error) {
+	next, err := c.resolveURL(path, query)
+	if err != nil {
 		return nil, err
 	}
-	return payload.WorkflowRuns, nil
+
+	var items []workflowRunPayload
+	for page := 0; next != "" && page < c.maxPages; page++ {
+		var payload workflowRunsResponse
+		header, err := c.getJSONURL(ctx, next, &payload)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, payload.WorkflowRuns...)
+		if since == 0 || len(payload.WorkflowRuns) == 0 || payload.WorkflowRuns[len(payload.WorkflowRuns)-1].ID <= since {
+			break
+		}
+		next = nextPageURL(header.Get("Link"))
+	}
+	return items, nil
+}
+
+// escapeRef escapes each segment of a ref that may contain slashes.
+func escapeRef(ref string) string {
+	parts := strings.Split(ref, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func (c *Client) listRuns(ctx context.Context, owner, repo string, query map[string]string) ([]workflowRunPayload, bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs", owner, repo)
+	return listAll(ctx, c, path, query, func(p workflowRunsResponse) []workflowRunPayload {
+		return p.WorkflowRuns
+	})
+}
+
+// listAll follows Link rel="next" headers until GitHub runs out of pages or
+// the client's page cap is reached, collecting the items extract pulls out of
+// each page. The boolean reports whether results were cut short by the cap.
+func listAll[P, I any](ctx context.Context, c *Client, path string, query map[string]string, extract func(P) []I) ([]I, bool, error) {
+	next, err := c.resolveURL(path, query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []I
+	for page := 0; next != ""; page++ {
+		if page >= c.maxPages {
+			return items, true, nil
+		}
+		var payload P
+		header, err := c.getJSONURL(ctx, next, &payload)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, extract(payload)...)
+		next = nextPageURL(header.Get("Link"))
+	}
+	return items, false, nil
 }
 
 func (c *Client) getJSON(ctx context.Context, path string, query map[string]string, v any) error {
-	req, err := c.newRequest(ctx, path, query)
+	target, err := c.resolveURL(path, query)
 	if err != nil {
 		return err
 	}
+	_, err = c.getJSONURL(ctx, target, v)
+	return err
+}
+
+// getJSONURL fetches an absolute API URL and decodes the JSON body into v. The
+// response headers are returned so callers can inspect pagination links.
+// Requests are made conditional when a cached copy exists; a 304 reply is
+// decoded from the cache instead.
+func (c *Client) getJSONURL(ctx context.Context, target string, v any) (http.Header, error) {
+	if err := c.checkBackoff(resourceOf(target), time.Now()); err != nil {
+		return nil, err
+	}
 
-	res, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, http.MethodGet, target)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	cached, hasCached := c.cache.get(target)
+	if hasCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	res, err := c.do(req, true)
+	if err != nil {
+		return nil, err
 	}
 	defer res.Body.Close()
+	c.recordRateLimit(res.Header)
+
+	if res.StatusCode == http.StatusNotModified && hasCached {
+		header := res.Header.Clone()
+		if header.Get("Link") == "" && cached.Link != "" {
+			header.Set("Link", cached.Link)
+		}
+		return header, json.Unmarshal(cached.Body, v)
+	}
 
 	if res.StatusCode >= 400 {
-		body, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
-		msg := strings.TrimSpace(string(body))
-		if res.StatusCode == http.StatusUnauthorized {
-			authMsg := "GitHub authentication failed"
-			if msg != "" {
-				authMsg = fmt.Sprintf("%s: %s", authMsg, msg)
-			}
-			if suffix := tokenSuffix(c.token); suffix != "" {
-				authMsg = fmt.Sprintf("%s. Token: %s", authMsg, suffix)
-			}
-			return fmt.Errorf("%w: %s", ErrUnauthorized, authMsg)
+		return nil, c.responseError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	etag, lastModified := res.Header.Get("ETag"), res.Header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		c.cache.put(CacheEntry{
+			URL:          target,
+			ETag:         etag,
+			LastModified: lastModified,
+			Link:         res.Header.Get("Link"),
+			Body:         body,
+			StoredAt:     time.Now(),
+		})
+	}
+
+	return res.Header, json.Unmarshal(body, v)
+}
+
+// responseError converts a >= 400 response into the matching sentinel error.
+func (c *Client) responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+	msg := strings.TrimSpace(string(body))
+	if res.StatusCode == http.StatusUnauthorized {
+		authMsg := "GitHub authentication failed"
+		if msg != "" {
+			authMsg = fmt.Sprintf("%s: %s", authMsg, msg)
 		}
-		if res.StatusCode == http.StatusNotFound {
-			return fmt.Errorf("%w: %s", ErrNotFound, msg)
+		if suffix := tokenSuffix(c.token); suffix != "" {
+			authMsg = fmt.Sprintf("%s. Token: %s", authMsg, suffix)
 		}
-		return fmt.Errorf("github api error (%d): %s", res.StatusCode, msg)
+		return fmt.Errorf("%w: %s", ErrUnauthorized, authMsg)
 	}
-
-	return json.NewDecoder(res.Body).Decode(v)
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, msg)
+	}
+	if err := c.rateLimitFailure(res, msg, time.Now()); err != nil {
+		return err
+	}
+	return fmt.Errorf("github api error (%d): %s", res.StatusCode, msg)
 }
 
-func (c *Client) newRequest(ctx context.Context, resource string, query map[string]string) (*http.Request, error) {
+func (c *Client) resolveURL(resource string, query map[string]string) (string, error) {
 	u, err := url.Parse(c.baseURL + resource)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	if len(query) > 0 {
 		q := u.Query()
@@ -206,8 +612,11 @@ func (c *Client) newRequest(ctx context.Context, resource string, query map[stri
 		}
 		u.RawQuery = q.Encode()
 	}
+	return u.String(), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+func (c *Client) newRequest(ctx context.Context, method, target string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +630,27 @@ func (c *Client) newRequest(ctx context.Context, resource string, query map[stri
 	return req, nil
 }
 
+// nextPageURL extracts the rel="next" target from a Link header, e.g.
+// `<https://api.github.com/...&page=2>; rel="next", <...>; rel="last"`.
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(sections[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range sections[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(target, "<>")
+			}
+		}
+	}
+	return ""
+}
+
 func decorateRuns(payload []workflowRunPayload, cb func(*WorkflowRun)) []WorkflowRun {
 	out := make([]WorkflowRun, 0, len(payload))
 	for _, item := range payload {
@@ -236,6 +666,7 @@ func decorateRuns(payload []workflowRunPayload, cb func(*WorkflowRun)) []Workflo
 func convertRun(payload workflowRunPayload) WorkflowRun {
 	run := WorkflowRun{
 		ID:            payload.ID,
+		NodeID:        payload.NodeID,
 		Name:          firstNonEmpty(payload.DisplayTitle, payload.Name),
 		WorkflowName:  payload.Name,
 		Status:        summarizeStatus(payload.Status, payload.Conclusion),
@@ -244,6 +675,10 @@ func convertRun(payload workflowRunPayload) WorkflowRun {
 		HeadBranch:    payload.HeadBranch,
 		HeadSHA:       payload.HeadSHA,
 		Event:         payload.Event,
+		CheckSuiteID:  payload.CheckSuiteID,
+		Attempt:       payload.RunAttempt,
+		CreatedAt:     payload.CreatedAt,
+		StartedAt:     payload.RunStartedAt,
 		LastUpdatedAt: payload.UpdatedAt,
 	}
 	if payload.Repository.FullName != "" {
@@ -286,16 +721,24 @@ func cleanTarget(branch, event string) string {
 
 func summarizeStatus(status, conclusion string) RunStatus {
 	switch status {
-	case "queued", "in_progress", "waiting", "requested":
-		return RunStatusPending
+	case "waiting":
+		return RunStatusWaiting
 	case "completed":
 		switch conclusion {
 		case "success":
 			return RunStatusSuccess
-		case "failure", "timed_out", "cancelled", "startup_failure", "stale":
+		case "failure", "timed_out", "startup_failure":
 			return RunStatusFailed
+		case "cancelled":
+			return RunStatusCancelled
+		case "skipped":
+			return RunStatusSkipped
+		case "action_required":
+			return RunStatusActionRequired
 		default:
-			return RunStatusPending
+			// neutral, stale, or a conclusion GitHub added after this was
+			// written: the run is over either way, so don't leave it pending.
+			return RunStatusNeutral
 		}
 	default:
 		return RunStatusPending
@@ -317,26 +760,28 @@ type workflowRunsResponse struct {
 }
 
 type workflowRunPayload struct {
-	ID            int64                    `json:"id"`
-	Name          string                   `json:"name"`
-	DisplayTitle  string                   `json:"display_title"`
-	Event         string                   `json:"event"`
-	Status        string                   `json:"status"`
-	Conclusion    string                   `json:"conclusion"`
-	HTMLURL       string                   `json:"html_url"`
-	HeadBranch    string                   `json:"head_branch"`
-	HeadSHA       string                   `json:"head_sha"`
-	UpdatedAt     time.Time                `json:"updated_at"`
-	PullRequests  []workflowRunPullRequest `json:"pull_requests"`
-	Repository    workflowRunRepository    `json:"repository"`
-	RunStartedAt  time.Time                `json:"run_started_at"`
-	HeadCommit    workflowRunHeadCommit    `json:"head_commit"`
-	WorkflowID    int64                    `json:"workflow_id"`
-	WorkflowName  string                   `json:"workflow_name"`
-	OriginalTotal int                      `json:"run_attempt"`
-	Links         workflowRunLinks         `json:"links"`
-	Path          string                   `json:"path"`
-	CreatedAt     time.Time                `json:"created_at"`
+	ID           int64                    `json:"id"`
+	NodeID       string                   `json:"node_id"`
+	Name         string                   `json:"name"`
+	DisplayTitle string                   `json:"display_title"`
+	Event        string                   `json:"event"`
+	Status       string                   `json:"status"`
+	Conclusion   string                   `json:"conclusion"`
+	HTMLURL      string                   `json:"html_url"`
+	HeadBranch   string                   `json:"head_branch"`
+	HeadSHA      string                   `json:"head_sha"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+	PullRequests []workflowRunPullRequest `json:"pull_requests"`
+	Repository   workflowRunRepository    `json:"repository"`
+	RunStartedAt time.Time                `json:"run_started_at"`
+	HeadCommit   workflowRunHeadCommit    `json:"head_commit"`
+	WorkflowID   int64                    `json:"workflow_id"`
+	CheckSuiteID int64                    `json:"check_suite_id"`
+	WorkflowName string                   `json:"workflow_name"`
+	RunAttempt   int                      `json:"run_attempt"`
+	Links        workflowRunLinks         `json:"links"`
+	Path         string                   `json:"path"`
+	CreatedAt    time.Time                `json:"created_at"`
 }
 
 type workflowRunRepository struct {
@@ -363,8 +808,16 @@ type workflowRunLinks struct {
 	} `json:"workflow"`
 }
 
+type branchPayload struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
 type pullRequestPayload struct {
-	Number int `json:"number"`
+	Number int    `json:"number"`
+	State  string `json:"state"`
 	Head   struct {
 		SHA string `json:"sha"`
 		Ref string `json:"ref"`
@@ -374,7 +827,11 @@ type pullRequestPayload struct {
 
 // ErrNotFound can be returned when GitHub responds with 404.
 // ErrUnauthorized indicates GitHub rejected the supplied token (401).
+// ErrRateLimited wraps every RateLimitError.
+// ErrWriteDisabled is returned by write operations unless SetAllowWrite(true).
 var (
-	ErrNotFound     = errors.New("resource not found")
-	ErrUnauthorized = errors.New("authentication failed")
+	ErrNotFound      = errors.New("resource not found")
+	ErrUnauthorized  = errors.New("authentication failed")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrWriteDisabled = errors.New("write operations are disabled")
 )
@@ -0,0 +1,697 @@
+package githubclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New("test-token")
+	client.baseURL = server.URL
+	client.retryBaseDelay = time.Millisecond
+	return client
+}
+
+func pagedRunsHandler(pages int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < pages {
+			next := fmt.Sprintf("<http://%s%s?head_sha=abc&per_page=100&page=%d>; rel=\"next\"", r.Host, r.URL.Path, page+1)
+			w.Header().Set("Link", next)
+		}
+		fmt.Fprintf(w, `{"workflow_runs":[{"id":%d,"name":"CI","status":"completed","conclusion":"success"}]}`, page)
+	})
+}
+
+func TestRunsByCommitFollowsPagination(t *testing.T) {
+	client := newTestClient(t, pagedRunsHandler(3))
+
+	runs, err := client.RunsByCommit(context.Background(), "owner", "repo", "abc")
+	if err != nil {
+		t.Fatalf("RunsByCommit returned error: %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs across pages, got %d", len(runs))
+	}
+	for _, run := range runs {
+		if run.Truncated {
+			t.Fatalf("run %d unexpectedly marked truncated", run.ID)
+		}
+	}
+}
+
+func TestRunsByCommitMarksTruncatedAtPageCap(t *testing.T) {
+	client := newTestClient(t, pagedRunsHandler(5))
+	client.SetMaxPages(2)
+
+	runs, err := client.RunsByCommit(context.Background(), "owner", "repo", "abc")
+	if err != nil {
+		t.Fatalf("RunsByCommit returned error: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs before hitting cap, got %d", len(runs))
+	}
+	if !runs[0].Truncated {
+		t.Fatal("expected runs to be flagged as truncated")
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	link := `<https://api.github.com/x?page=2>; rel="next", <https://api.github.com/x?page=9>; rel="last"`
+	if got := nextPageURL(link); got != "https://api.github.com/x?page=2" {
+		t.Fatalf("unexpected next URL: %q", got)
+	}
+	if got := nextPageURL(`<https://api.github.com/x?page=1>; rel="prev"`); got != "" {
+		t.Fatalf("expected no next URL, got %q", got)
+	}
+}
+
+func TestConditionalRequestServedFromCache(t *testing.T) {
+	var hits, notModified int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":7,"name":"CI","status":"completed","conclusion":"success"}`)
+	}))
+
+	for i := 0; i < 2; i++ {
+		run, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 7)
+		if err != nil {
+			t.Fatalf("WorkflowRunByID returned error: %v", err)
+		}
+		if run.ID != 7 || run.Status != RunStatusSuccess {
+			t.Fatalf("unexpected run on attempt %d: %#v", i, run)
+		}
+	}
+	if hits != 2 || notModified != 1 {
+		t.Fatalf("expected second request to be a 304, got hits=%d notModified=%d", hits, notModified)
+	}
+}
+
+func TestImportCacheSkipsOtherHosts(t *testing.T) {
+	client := New("")
+	client.ImportCache([]CacheEntry{
+		{URL: "https://api.github.com/repos/a/b/actions/runs/1", ETag: `"x"`, Body: []byte("{}")},
+		{URL: "https://ghe.example.com/api/v3/repos/a/b/actions/runs/1", ETag: `"y"`, Body: []byte("{}")},
+	})
+	entries := client.ExportCache()
+	if len(entries) != 1 || entries[0].ETag != `"x"` {
+		t.Fatalf("expected only the api.github.com entry, got %#v", entries)
+	}
+}
+
+func TestRateLimitedResponsePausesClient(t *testing.T) {
+	var hits int
+	reset := time.Now().Add(time.Hour).Unix()
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+	}))
+
+	_, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 1)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rateErr.RetryAt.Unix() != reset {
+		t.Fatalf("expected retry at reset time %d, got %d", reset, rateErr.RetryAt.Unix())
+	}
+	if limit := client.RateLimit(); limit.Limit != 60 || limit.Remaining != 0 {
+		t.Fatalf("unexpected rate limit snapshot: %#v", limit)
+	}
+
+	if _, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 1); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected client to stay paused, got %v", err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected paused client to skip the network, got %d hits", hits)
+	}
+}
+
+func TestSearchRateLimitLeavesCoreRequests(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/") {
+			w.Header().Set("X-RateLimit-Resource", "search")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":7,"status":"completed","conclusion":"success"}`)
+	}))
+
+	if _, _, err := client.SearchPullRequests(context.Background(), "is:pr is:open author:@me"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected a search rate limit, got %v", err)
+	}
+	if _, _, err := client.SearchPullRequests(context.Background(), "is:pr is:open author:@me"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected search to stay paused, got %v", err)
+	}
+	run, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 7)
+	if err != nil {
+		t.Fatalf("expected core requests to keep working, got %v", err)
+	}
+	if run.ID != 7 {
+		t.Fatalf("unexpected run %#v", run)
+	}
+}
+
+func TestNewEnterprisePrefersHostToken(t *testing.T) {
+	t.Setenv("GH_ENTERPRISE_TOKEN", "shared")
+	t.Setenv("GH_ENTERPRISE_TOKEN_GHE_EXAMPLE_COM", "per-host")
+
+	if env := EnterpriseTokenEnv("ghe.example.com"); env != "GH_ENTERPRISE_TOKEN_GHE_EXAMPLE_COM" {
+		t.Fatalf("EnterpriseTokenEnv = %q", env)
+	}
+	if token := NewEnterprise("GHE.example.com", "").token; token != "per-host" {
+		t.Fatalf("expected the host's own token, got %q", token)
+	}
+	if token := NewEnterprise("other.example.com", "").token; token != "shared" {
+		t.Fatalf("expected the shared token as a fallback, got %q", token)
+	}
+	if token := NewEnterprise("ghe.example.com", "explicit").token; token != "explicit" {
+		t.Fatalf("expected an explicit token to win, got %q", token)
+	}
+}
+
+func TestNewEnterpriseUsesAPIv3AndTagsRuns(t *testing.T) {
+	client := NewEnterprise("GHE.example.com", "token")
+	if client.baseURL != "https://ghe.example.com/api/v3" {
+		t.Fatalf("unexpected enterprise base URL: %s", client.baseURL)
+	}
+
+	server := httptest.NewServer(pagedRunsHandler(1))
+	defer server.Close()
+	client.baseURL = server.URL
+
+	runs, err := client.RunsByCommit(context.Background(), "owner", "repo", "abc")
+	if err != nil {
+		t.Fatalf("RunsByCommit returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Host != "ghe.example.com" {
+		t.Fatalf("expected run tagged with enterprise host, got %#v", runs)
+	}
+	if runs[0].TargetURL != "https://ghe.example.com/owner/repo/commit/abc" {
+		t.Fatalf("unexpected target URL: %s", runs[0].TargetURL)
+	}
+}
+
+func TestRunsByBranchFollowsHead(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/branches/feature/login":
+			fmt.Fprint(w, `{"name":"feature/login","commit":{"sha":"abc1234def"}}`)
+		case "/repos/owner/repo/actions/runs":
+			if got := r.URL.Query().Get("head_sha"); got != "abc1234def" {
+				t.Errorf("expected runs for the branch head, got head_sha=%s", got)
+			}
+			fmt.Fprint(w, `{"total_count":1,"workflow_runs":[{"id":3,"name":"CI","status":"in_progress","repository":{"full_name":"owner/repo"}}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	runs, err := client.RunsByBranch(context.Background(), "owner", "repo", "feature/login")
+	if err != nil {
+		t.Fatalf("RunsByBranch returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Target != "branch feature/login" || runs[0].TargetURL != "https://github.com/owner/repo/tree/feature/login" {
+		t.Fatalf("unexpected runs: %#v", runs)
+	}
+}
+
+func TestOpenPullRequestForBranch(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/repos/owner/repo/pulls" || q.Get("state") != "open" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if q.Get("head") == "owner:feature" {
+			fmt.Fprint(w, `[{"number":12,"head":{"sha":"abc1234","ref":"feature"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+
+	number, err := client.OpenPullRequestForBranch(context.Background(), "owner", "repo", "feature")
+	if err != nil || number != 12 {
+		t.Fatalf("OpenPullRequestForBranch = %d, %v", number, err)
+	}
+	number, err = client.OpenPullRequestForBranch(context.Background(), "owner", "repo", "main")
+	if err != nil || number != 0 {
+		t.Fatalf("OpenPullRequestForBranch = %d, %v; expected no PR", number, err)
+	}
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/issues":
+			if got := r.URL.Query().Get("q"); got != "is:pr is:open author:@me" {
+				t.Errorf("unexpected query %q", got)
+			}
+			w.Header().Set("X-RateLimit-Resource", "search")
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.Header().Set("X-RateLimit-Remaining", "29")
+			fmt.Fprintf(w, `{"items":[
+				{"number":5,"repository_url":"%[1]s/repos/owner/repo","pull_request":{}},
+				{"number":6,"repository_url":"%[1]s/repos/owner/repo"}]}`, "https://api.github.com")
+		case "/repos/owner/repo/pulls/5":
+			fmt.Fprint(w, `{"number":5,"state":"closed"}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	refs, truncated, err := client.SearchPullRequests(context.Background(), "is:pr is:open author:@me")
+	if err != nil {
+		t.Fatalf("SearchPullRequests returned error: %v", err)
+	}
+	if truncated || len(refs) != 1 || refs[0] != (PullRequestRef{Owner: "owner", Repo: "repo", Number: 5}) {
+		t.Fatalf("expected only the pull request, got %#v", refs)
+	}
+	if limit := client.RateLimit(); limit.Limit != 0 {
+		t.Fatalf("search quota should not replace the core one, got %#v", limit)
+	}
+
+	open, err := client.PullRequestOpen(context.Background(), "owner", "repo", 5)
+	if err != nil || open {
+		t.Fatalf("PullRequestOpen = %v, %v; expected closed", open, err)
+	}
+}
+
+func TestSearchPullRequestsFollowsPagination(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		next := fmt.Sprintf("<http://%s%s?q=is%%3Apr&per_page=100&page=%d>; rel=\"next\"", r.Host, r.URL.Path, page+1)
+		w.Header().Set("Link", next)
+		fmt.Fprintf(w, `{"items":[{"number":%d,"repository_url":"https://api.github.com/repos/owner/repo","pull_request":{}}]}`, page)
+	}))
+	client.SetMaxPages(2)
+
+	refs, truncated, err := client.SearchPullRequests(context.Background(), "is:pr")
+	if err != nil {
+		t.Fatalf("SearchPullRequests returned error: %v", err)
+	}
+	if len(refs) != 2 || refs[1].Number != 2 || !truncated {
+		t.Fatalf("expected 2 truncated pages of results, got %#v (truncated=%v)", refs, truncated)
+	}
+}
+
+func TestRecentRunsAppliesFilters(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/repos/owner/repo/actions/runs" || q.Get("branch") != "main" || q.Get("event") != "push" || q.Has("actor") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		fmt.Fprint(w, `{"total_count":2,"workflow_runs":[
+			{"id":2,"name":"Deploy","path":".github/workflows/deploy.yml","status":"queued"},
+			{"id":1,"name":"CI","path":".github/workflows/ci.yml","status":"completed","conclusion":"success"}]}`)
+	}))
+
+	runs, err := client.RecentRuns(context.Background(), "owner", "repo", RunFilter{Workflow: "deploy", Branch: "main", Event: "push"})
+	if err != nil {
+		t.Fatalf("RecentRuns returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 2 || runs[0].Host != "github.com" {
+		t.Fatalf("expected only the deploy run, got %#v", runs)
+	}
+}
+
+func TestRecentRunsUsesWorkflowEndpointForFiles(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/workflows/deploy.yml/runs" || r.URL.Query().Get("branch") != "main" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		fmt.Fprint(w, `{"total_count":1,"workflow_runs":[{"id":2,"name":"Deploy prod","path":".github/workflows/deploy.yml","status":"queued"}]}`)
+	}))
+
+	runs, err := client.RecentRuns(context.Background(), "owner", "repo", RunFilter{Workflow: "deploy.yml", Branch: "main"})
+	if err != nil {
+		t.Fatalf("RecentRuns returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 2 {
+		t.Fatalf("expected the workflow's run, got %#v", runs)
+	}
+}
+
+func TestRecentRunsPagesBackToSince(t *testing.T) {
+	// 70 runs, newest first, alternating between CI (odd IDs) and Deploy.
+	var requests int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		var items []string
+		for id := 70 - (page-1)*30; id > max(0, 70-page*30); id-- {
+			name := "CI"
+			if id%2 == 0 {
+				name = "Deploy"
+			}
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":%q,"status":"completed","conclusion":"success"}`, id, name))
+		}
+		if page*30 < 70 {
+			w.Header().Set("Link", fmt.Sprintf("<http://%s%s?per_page=30&page=%d>; rel=\"next\"", r.Host, r.URL.Path, page+1))
+		}
+		fmt.Fprintf(w, `{"workflow_runs":[%s]}`, strings.Join(items, ","))
+	}))
+
+	runs, err := client.RecentRuns(context.Background(), "owner", "repo", RunFilter{Workflow: "Deploy", Since: 30})
+	if err != nil {
+		t.Fatalf("RecentRuns returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected to stop at the page reaching run 30, got %d requests", requests)
+	}
+	newer := 0
+	for _, run := range runs {
+		if run.WorkflowName != "Deploy" && run.Name != "Deploy" {
+			t.Fatalf("unexpected run %#v", run)
+		}
+		if run.ID > 30 {
+			newer++
+		}
+	}
+	if newer != 20 {
+		t.Fatalf("expected all 20 Deploy runs newer than 30, got %d", newer)
+	}
+
+	requests = 0
+	if _, err := client.RecentRuns(context.Background(), "owner", "repo", RunFilter{Workflow: "Deploy"}); err != nil {
+		t.Fatalf("RecentRuns returned error: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected a first fetch to read one page, got %d requests", requests)
+	}
+}
+
+func TestRunsByWorkflow(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/workflows/deploy.yml/runs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"total_count":1,"workflow_runs":[{"id":8,"name":"Deploy","status":"in_progress","head_branch":"main","event":"push"}]}`)
+	}))
+
+	runs, err := client.RunsByWorkflow(context.Background(), "owner", "repo", "deploy.yml")
+	if err != nil {
+		t.Fatalf("RunsByWorkflow returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 8 || runs[0].WorkflowName != "Deploy" || runs[0].Host != "github.com" {
+		t.Fatalf("unexpected runs: %#v", runs)
+	}
+}
+
+func TestJobsForRun(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/runs/9/jobs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"total_count":1,"jobs":[{"id":1,"run_id":9,"name":"test","status":"completed","conclusion":"failure","runner_name":"ubuntu-1",
+			"steps":[{"number":1,"name":"checkout","status":"completed","conclusion":"success"},{"number":2,"name":"go test","status":"completed","conclusion":"failure"}]}]}`)
+	}))
+
+	jobs, err := client.JobsForRun(context.Background(), "owner", "repo", 9)
+	if err != nil {
+		t.Fatalf("JobsForRun returned error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Status != RunStatusFailed || jobs[0].RunnerName != "ubuntu-1" {
+		t.Fatalf("unexpected jobs: %#v", jobs)
+	}
+	if len(jobs[0].Steps) != 2 || jobs[0].Steps[1].Status != RunStatusFailed {
+		t.Fatalf("unexpected steps: %#v", jobs[0].Steps)
+	}
+}
+
+func TestJobByID(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/jobs/77" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id":77,"run_id":9,"name":"lint","status":"in_progress"}`)
+	}))
+
+	job, err := client.JobByID(context.Background(), "owner", "repo", 77)
+	if err != nil {
+		t.Fatalf("JobByID returned error: %v", err)
+	}
+	if job.ID != 77 || job.RunID != 9 || job.Status != RunStatusPending {
+		t.Fatalf("unexpected job: %#v", job)
+	}
+}
+
+func TestJobLogsFollowsRedirect(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("authorization header leaked to log storage host")
+		}
+		fmt.Fprint(w, "2025-01-01T00:00:00.0000000Z ##[error]boom\n")
+	}))
+	defer storage.Close()
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/jobs/5/logs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		http.Redirect(w, r, storage.URL+"/blob", http.StatusFound)
+	}))
+
+	logs, err := client.JobLogs(context.Background(), "owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("JobLogs returned error: %v", err)
+	}
+	if logs != "2025-01-01T00:00:00.0000000Z ##[error]boom\n" {
+		t.Fatalf("unexpected log body: %q", logs)
+	}
+}
+
+func TestReadHeadTailKeepsEndOfLongLogs(t *testing.T) {
+	short, err := readHeadTail(strings.NewReader("setup\nerror\n"), 8, 8)
+	if err != nil || short != "setup\nerror\n" {
+		t.Fatalf("readHeadTail = %q, %v; expected the whole log", short, err)
+	}
+
+	log := "setup\n" + strings.Repeat("x", 1000) + "\n##[error]boom\n"
+	got, err := readHeadTail(strings.NewReader(log), 6, 14)
+	if err != nil {
+		t.Fatalf("readHeadTail returned error: %v", err)
+	}
+	if got != "setup\n\n… 1001 bytes of log omitted …\n##[error]boom\n" {
+		t.Fatalf("unexpected truncated log: %q", got)
+	}
+}
+
+func TestAnnotationsForCheckSuite(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/check-suites/3/check-runs":
+			fmt.Fprint(w, `{"check_runs":[{"id":20,"name":"lint","output":{"annotations_count":1}},{"id":21,"name":"build","output":{"annotations_count":0}}]}`)
+		case "/repos/owner/repo/check-runs/20/annotations":
+			fmt.Fprint(w, `[{"path":"main.go","start_line":12,"end_line":12,"annotation_level":"failure","message":"unused variable"}]`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	annotations, err := client.AnnotationsForCheckSuite(context.Background(), "owner", "repo", 3)
+	if err != nil {
+		t.Fatalf("AnnotationsForCheckSuite returned error: %v", err)
+	}
+	if len(annotations) != 1 || annotations[0].CheckRunName != "lint" || annotations[0].Location() != "main.go:12" {
+		t.Fatalf("unexpected annotations: %#v", annotations)
+	}
+}
+
+func TestWriteOperationsRequireOptIn(t *testing.T) {
+	var method, path string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if err := client.RerunFailedJobs(context.Background(), "owner", "repo", 4); !errors.Is(err, ErrWriteDisabled) {
+		t.Fatalf("expected ErrWriteDisabled, got %v", err)
+	}
+	if path != "" {
+		t.Fatalf("expected no request while writes are disabled, got %s", path)
+	}
+
+	client.SetAllowWrite(true)
+	if err := client.RerunFailedJobs(context.Background(), "owner", "repo", 4); err != nil {
+		t.Fatalf("RerunFailedJobs returned error: %v", err)
+	}
+	if method != http.MethodPost || path != "/repos/owner/repo/actions/runs/4/rerun-failed-jobs" {
+		t.Fatalf("unexpected request %s %s", method, path)
+	}
+}
+
+func TestRunStatesAndPullRequestHeadsUseGraphQL(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if strings.Contains(req.Query, "nodes(ids:") {
+			fmt.Fprint(w, `{"data":{"nodes":[
+				{"id":"WFR_1","databaseId":1,"updatedAt":"2025-01-01T00:00:00Z","checkSuite":{"status":"COMPLETED","conclusion":"TIMED_OUT"}},
+				null]},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a node with the global id of 'WFR_2'"}]}`)
+			return
+		}
+		if req.Variables["o0"] != "owner" || req.Variables["n0"] != float64(7) {
+			t.Errorf("unexpected variables %v", req.Variables)
+		}
+		fmt.Fprint(w, `{"data":{"pr0":{"pullRequest":{"headRefOid":"abc123","commits":{"nodes":[{"commit":{"checkSuites":{"totalCount":3}}}]}}},"pr1":null}}`)
+	}))
+
+	states, err := client.RunStates(context.Background(), []string{"WFR_1", "WFR_2"})
+	if err != nil {
+		t.Fatalf("RunStates returned error: %v", err)
+	}
+	if len(states) != 1 || states["WFR_1"].Status != RunStatusFailed || states["WFR_1"].StatusDetail != "completed/timed_out" {
+		t.Fatalf("unexpected states: %#v", states)
+	}
+
+	pr := PullRequestRef{Owner: "owner", Repo: "repo", Number: 7}
+	heads, err := client.PullRequestHeads(context.Background(), []PullRequestRef{pr, {Owner: "owner", Repo: "gone", Number: 1}})
+	if err != nil {
+		t.Fatalf("PullRequestHeads returned error: %v", err)
+	}
+	if len(heads) != 1 || heads[pr] != (PullRequestHead{SHA: "abc123", CheckSuites: 3}) {
+		t.Fatalf("unexpected heads: %#v", heads)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	if got := New("").graphQLURL(); got != "https://api.github.com/graphql" {
+		t.Fatalf("unexpected github.com endpoint %s", got)
+	}
+	if got := NewEnterprise("ghe.example.com", "").graphQLURL(); got != "https://ghe.example.com/api/graphql" {
+		t.Fatalf("unexpected GHES endpoint %s", got)
+	}
+}
+
+func TestTransientErrorsAreRetried(t *testing.T) {
+	var requests int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id":5,"name":"CI","status":"completed","conclusion":"success"}`)
+	}))
+
+	run, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+	if run.ID != 5 || requests != 3 {
+		t.Fatalf("expected run 5 after 3 requests, got %d after %d", run.ID, requests)
+	}
+}
+
+func TestRetriesAreBounded(t *testing.T) {
+	var requests int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	if _, err := client.WorkflowRunByID(context.Background(), "owner", "repo", 5); err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if requests != DefaultMaxRetries+1 {
+		t.Fatalf("expected %d requests, got %d", DefaultMaxRetries+1, requests)
+	}
+
+	requests = 0
+	client.SetAllowWrite(true)
+	if err := client.CancelRun(context.Background(), "owner", "repo", 5); err == nil {
+		t.Fatal("expected the cancel to fail")
+	}
+	if requests != 1 {
+		t.Fatalf("expected writes not to be retried, got %d requests", requests)
+	}
+}
+
+func TestRetryWaitStopsWhenContextEnds(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	client.retryBaseDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WorkflowRunByID(ctx, "owner", "repo", 5)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context deadline, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("expected the retry wait to be cut short")
+	}
+}
+
+func TestSummarizeStatus(t *testing.T) {
+	cases := []struct {
+		status, conclusion string
+		want               RunStatus
+	}{
+		{"queued", "", RunStatusPending},
+		{"in_progress", "", RunStatusPending},
+		{"waiting", "", RunStatusWaiting},
+		{"completed", "success", RunStatusSuccess},
+		{"completed", "failure", RunStatusFailed},
+		{"completed", "timed_out", RunStatusFailed},
+		{"completed", "cancelled", RunStatusCancelled},
+		{"completed", "skipped", RunStatusSkipped},
+		{"completed", "neutral", RunStatusNeutral},
+		{"completed", "action_required", RunStatusActionRequired},
+		{"completed", "something_new", RunStatusNeutral},
+	}
+	for _, tc := range cases {
+		if got := summarizeStatus(tc.status, tc.conclusion); got != tc.want {
+			t.Errorf("summarizeStatus(%q, %q) = %q, want %q", tc.status, tc.conclusion, got, tc.want)
+		}
+		if got := tc.want.Completed(); got != (tc.status == "completed") {
+			t.Errorf("%q.Completed() = %v", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package githubclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// GitHub accepts at most 100 IDs in a single nodes() lookup.
+	maxNodesPerQuery = 100
+	// Aliased pull request lookups are cheap, but keep queries small enough
+	// that one slow repository doesn't hold up the rest for long.
+	maxPullRequestsPerQuery = 25
+)
+
+// ErrGraphQL wraps errors reported in a GraphQL response body.
+var ErrGraphQL = errors.New("github graphql error")
+
+// RunState is the part of a workflow run that GraphQL can report for many
+// runs in one query: enough to tell whether the run changed since last seen.
+type RunState struct {
+	NodeID       string
+	ID           int64
+	Status       RunStatus
+	StatusDetail string
+	UpdatedAt    time.Time
+}
+
+// PullRequestRef identifies a pull request for batched lookups.
+type PullRequestRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// PullRequestHead describes a pull request's current head commit. CheckSuites
+// grows as new workflows start on the head, so a change in either field means
+// the PR's runs need re-fetching.
+type PullRequestHead struct {
+	SHA         string
+	CheckSuites int
+}
+
+// graphQLURL derives the GraphQL endpoint from the REST base URL:
+// api.github.com/graphql on github.com and <host>/api/graphql on GHES.
+func (c *Client) graphQLURL() string {
+	if base, ok := strings.CutSuffix(c.baseURL, "/v3"); ok {
+		return base + "/graphql"
+	}
+	return c.baseURL + "/graphql"
+}
+
+// RunStates looks up the current state of workflow runs by node ID. Runs that
+// no longer exist are missing from the result.
+func (c *Client) RunStates(ctx context.Context, nodeIDs []string) (map[string]RunState, error) {
+	const query = `query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on WorkflowRun {
+      id
+      databaseId
+      updatedAt
+      checkSuite { status conclusion }
+    }
+  }
+}`
+	states := make(map[string]RunState, len(nodeIDs))
+	for start := 0; start < len(nodeIDs); start += maxNodesPerQuery {
+		ids := nodeIDs[start:min(start+maxNodesPerQuery, len(nodeIDs))]
+		var data struct {
+			Nodes []*struct {
+				ID         string    `json:"id"`
+				DatabaseID int64     `json:"databaseId"`
+				UpdatedAt  time.Time `json:"updatedAt"`
+				CheckSuite struct {
+					Status     string `json:"status"`
+					Conclusion string `json:"conclusion"`
+				} `json:"checkSuite"`
+			} `json:"nodes"`
+		}
+		if err := c.graphQL(ctx, query, map[string]any{"ids": ids}, &data); err != nil {
+			return nil, err
+		}
+		for _, node := range data.Nodes {
+			if node == nil || node.ID == "" {
+				continue
+			}
+			status := strings.ToLower(node.CheckSuite.Status)
+			conclusion := strings.ToLower(node.CheckSuite.Conclusion)
+			states[node.ID] = RunState{
+				NodeID:       node.ID,
+				ID:           node.DatabaseID,
+				Status:       summarizeStatus(status, conclusion),
+				StatusDetail: buildStatusDetail(status, conclusion),
+				UpdatedAt:    node.UpdatedAt,
+			}
+		}
+	}
+	return states, nil
+}
+
+// PullRequestHeads looks up the head commit of many pull requests using
+// aliased queries. Pull requests that can't be resolved are missing from the
+// result.
+func (c *Client) PullRequestHeads(ctx context.Context, prs []PullRequestRef) (map[PullRequestRef]PullRequestHead, error) {
+	heads := make(map[PullRequestRef]PullRequestHead, len(prs))
+	for start := 0; start < len(prs); start += maxPullRequestsPerQuery {
+		chunk := prs[start:min(start+maxPullRequestsPerQuery, len(prs))]
+
+		var params, fields []string
+		variables := make(map[string]any, len(chunk)*3)
+		for i, pr := range chunk {
+			params = append(params, fmt.Sprintf("$o%d: String!, $r%d: String!, $n%d: Int!", i, i, i))
+			fields = append(fields, fmt.Sprintf(`pr%d: repository(owner: $o%d, name: $r%d) {
+    pullRequest(number: $n%d) {
+      headRefOid
+      commits(last: 1) { nodes { commit { checkSuites { totalCount } } } }
+    }
+  }`, i, i, i, i))
+			variables[fmt.Sprintf("o%d", i)] = pr.Owner
+			variables[fmt.Sprintf("r%d", i)] = pr.Repo
+			variables[fmt.Sprintf("n%d", i)] = pr.Number
+		}
+		query := fmt.Sprintf("query(%s) {\n  %s\n}", strings.Join(params, ", "), strings.Join(fields, "\n  "))
+
+		var data map[string]*struct {
+			PullRequest *struct {
+				HeadRefOid string `json:"headRefOid"`
+				Commits    struct {
+					Nodes []struct {
+						Commit struct {
+							CheckSuites struct {
+								TotalCount int `json:"totalCount"`
+							} `json:"checkSuites"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		}
+		if err := c.graphQL(ctx, query, variables, &data); err != nil {
+			return nil, err
+		}
+		for i, pr := range chunk {
+			repo := data[fmt.Sprintf("pr%d", i)]
+			if repo == nil || repo.PullRequest == nil {
+				continue
+			}
+			head := PullRequestHead{SHA: repo.PullRequest.HeadRefOid}
+			if nodes := repo.PullRequest.Commits.Nodes; len(nodes) > 0 {
+				head.CheckSuites = nodes[0].Commit.CheckSuites.TotalCount
+			}
+			heads[pr] = head
+		}
+	}
+	return heads, nil
+}
+
+// graphQL posts a query and decodes its data into v. Errors for individual
+// nodes (e.g. a deleted run) are tolerated as long as some data came back.
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]any, v any) error {
+	if err := c.checkBackoff(resourceGraphQL, time.Now()); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, c.graphQLURL())
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.do(req, true)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// GraphQL has its own points budget; the quota shown in the status line
+	// stays the REST one, so the headers are not recorded here.
+	if res.StatusCode >= 400 {
+		return c.responseError(res)
+	}
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return err
+	}
+	if len(payload.Data) == 0 || string(payload.Data) == "null" {
+		if len(payload.Errors) == 0 {
+			return fmt.Errorf("%w: empty response", ErrGraphQL)
+		}
+		for _, e := range payload.Errors {
+			if e.Type == "RATE_LIMITED" {
+				return &RateLimitError{RetryAt: time.Now().Add(time.Minute), Message: e.Message}
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrGraphQL, payload.Errors[0].Message)
+	}
+	return json.Unmarshal(payload.Data, v)
+}
@@ -0,0 +1,111 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Job is the normalized subset of a workflow job shown in the detail pane.
+type Job struct {
+	ID           int64
+	RunID        int64
+	Name         string
+	Status       RunStatus
+	StatusDetail string
+	RunnerName   string
+	HTMLURL      string
+	StartedAt    time.Time
+	CompletedAt  time.Time
+	Steps        []Step
+}
+
+// Step is a single step within a job.
+type Step struct {
+	Number       int
+	Name         string
+	Status       RunStatus
+	StatusDetail string
+	StartedAt    time.Time
+	CompletedAt  time.Time
+}
+
+// JobsForRun fetches the jobs (and their steps) for the latest attempt of a
+// workflow run.
+func (c *Client) JobsForRun(ctx context.Context, owner, repo string, runID int64) ([]Job, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/jobs", owner, repo, runID)
+	query := map[string]string{"per_page": runsPerPage}
+	payload, _, err := listAll(ctx, c, path, query, func(p jobsResponse) []jobPayload {
+		return p.Jobs
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(payload))
+	for _, item := range payload {
+		jobs = append(jobs, convertJob(item))
+	}
+	return jobs, nil
+}
+
+// JobByID fetches a single job. For Actions, a check run ID is also a job ID,
+// so this resolves check run links to their workflow run via Job.RunID.
+func (c *Client) JobByID(ctx context.Context, owner, repo string, jobID int64) (Job, error) {
+	var payload jobPayload
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/actions/jobs/%d", owner, repo, jobID), nil, &payload); err != nil {
+		return Job{}, err
+	}
+	return convertJob(payload), nil
+}
+
+func convertJob(payload jobPayload) Job {
+	job := Job{
+		ID:           payload.ID,
+		RunID:        payload.RunID,
+		Name:         payload.Name,
+		Status:       summarizeStatus(payload.Status, payload.Conclusion),
+		StatusDetail: buildStatusDetail(payload.Status, payload.Conclusion),
+		RunnerName:   payload.RunnerName,
+		HTMLURL:      payload.HTMLURL,
+		StartedAt:    payload.StartedAt,
+		CompletedAt:  payload.CompletedAt,
+	}
+	for _, step := range payload.Steps {
+		job.Steps = append(job.Steps, Step{
+			Number:       step.Number,
+			Name:         step.Name,
+			Status:       summarizeStatus(step.Status, step.Conclusion),
+			StatusDetail: buildStatusDetail(step.Status, step.Conclusion),
+			StartedAt:    step.StartedAt,
+			CompletedAt:  step.CompletedAt,
+		})
+	}
+	return job
+}
+
+type jobsResponse struct {
+	Jobs []jobPayload `json:"jobs"`
+}
+
+type jobPayload struct {
+	ID          int64         `json:"id"`
+	RunID       int64         `json:"run_id"`
+	Name        string        `json:"name"`
+	Status      string        `json:"status"`
+	Conclusion  string        `json:"conclusion"`
+	HTMLURL     string        `json:"html_url"`
+	RunnerName  string        `json:"runner_name"`
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+	Steps       []stepPayload `json:"steps"`
+}
+
+type stepPayload struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
@@ -0,0 +1,86 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// GitHub logs for long jobs can run to hundreds of megabytes. Only the first
+// logHeadBytes (runner and checkout setup) and the last logTailBytes, where
+// the failing step and its errors almost always are, are kept in memory.
+const (
+	logHeadBytes = 1 << 20
+	logTailBytes = 15 << 20
+)
+
+// JobLogs downloads the plain-text log for a job. GitHub answers with a
+// redirect to short-lived blob storage, which the HTTP client follows (the
+// Authorization header is not forwarded to the other host).
+func (c *Client) JobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	if err := c.checkBackoff(resourceCore, time.Now()); err != nil {
+		return "", err
+	}
+
+	target, err := c.resolveURL(fmt.Sprintf("/repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID), nil)
+	if err != nil {
+		return "", err
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, target)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.do(req, true)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	c.recordRateLimit(res.Header)
+
+	if res.StatusCode >= 400 {
+		return "", c.responseError(res)
+	}
+
+	return readHeadTail(res.Body, logHeadBytes, logTailBytes)
+}
+
+// readHeadTail reads r, keeping its first head and last tail bytes. When
+// anything in between is dropped, a marker line says how much.
+func readHeadTail(r io.Reader, head, tail int) (string, error) {
+	start, err := io.ReadAll(io.LimitReader(r, int64(head)))
+	if err != nil || len(start) < head {
+		return string(start), err
+	}
+
+	var (
+		end     []byte
+		dropped int64
+		chunk   = make([]byte, 64<<10)
+	)
+	for {
+		n, err := r.Read(chunk)
+		end = append(end, chunk[:n]...)
+		if len(end) > 2*tail {
+			// Compact only now and then so this doesn't copy on every read.
+			dropped += int64(len(end) - tail)
+			end = append(end[:0], end[len(end)-tail:]...)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(end) > tail {
+		dropped += int64(len(end) - tail)
+		end = end[len(end)-tail:]
+	}
+	if dropped == 0 {
+		return string(start) + string(end), nil
+	}
+	return fmt.Sprintf("%s\n… %d bytes of log omitted …\n%s", start, dropped, end), nil
+}
@@ -0,0 +1,141 @@
+package githubclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHub keeps separate quotas per API resource, so running out of the small
+// search one must not hold back the core REST requests that polling needs.
+const (
+	resourceCore    = "core"
+	resourceSearch  = "search"
+	resourceGraphQL = "graphql"
+)
+
+// RateLimit is the most recent quota snapshot GitHub reported to the client.
+// Limit is zero until the first response has been seen.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError is returned when GitHub rejects a request because the quota
+// is exhausted, or when the client refuses to send one until RetryAt. It
+// matches ErrRateLimited via errors.Is.
+type RateLimitError struct {
+	RetryAt time.Time
+	Message string
+}
+
+func (e *RateLimitError) Error() string {
+	text := fmt.Sprintf("GitHub rate limit exceeded; retry after %s", e.RetryAt.Local().Format("15:04:05"))
+	if e.Message != "" {
+		text = fmt.Sprintf("%s: %s", text, e.Message)
+	}
+	return text
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RateLimit returns the latest quota reported by GitHub.
+func (c *Client) RateLimit() RateLimit {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rate
+}
+
+// checkBackoff short-circuits requests against a resource while a previous
+// response told us to wait, so a polling loop can't keep burning a depleted
+// quota.
+func (c *Client) checkBackoff(resource string, now time.Time) error {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if until := c.blockedUntil[resource]; now.Before(until) {
+		return &RateLimitError{RetryAt: until}
+	}
+	return nil
+}
+
+// resourceOf names the rate limit resource an API URL counts against.
+func resourceOf(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return resourceCore
+	}
+	switch {
+	case strings.Contains(u.Path, "/search/"):
+		return resourceSearch
+	case strings.HasSuffix(u.Path, "/graphql"):
+		return resourceGraphQL
+	default:
+		return resourceCore
+	}
+}
+
+func (c *Client) recordRateLimit(header http.Header) {
+	// Search has its own small per-minute quota; the status line shows the
+	// core one.
+	if resource := header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+	limit, limitErr := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if limitErr != nil || remainingErr != nil {
+		return
+	}
+	snapshot := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		snapshot.Reset = time.Unix(reset, 0)
+	}
+
+	c.rateMu.Lock()
+	c.rate = snapshot
+	c.rateMu.Unlock()
+}
+
+// rateLimitFailure inspects an error response and, when it is a primary or
+// secondary rate limit, records the backoff and returns a RateLimitError.
+func (c *Client) rateLimitFailure(res *http.Response, msg string, now time.Time) error {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	retryAfter := res.Header.Get("Retry-After")
+	remaining := res.Header.Get("X-RateLimit-Remaining")
+	if res.StatusCode == http.StatusForbidden && retryAfter == "" && remaining != "0" {
+		return nil
+	}
+
+	retryAt := now.Add(time.Minute)
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		retryAt = now.Add(time.Duration(seconds) * time.Second)
+	} else if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && remaining == "0" {
+		retryAt = time.Unix(reset, 0)
+	}
+
+	resource := res.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = resourceCore
+		if res.Request != nil {
+			resource = resourceOf(res.Request.URL.String())
+		}
+	}
+
+	c.rateMu.Lock()
+	if c.blockedUntil == nil {
+		c.blockedUntil = make(map[string]time.Time)
+	}
+	if retryAt.After(c.blockedUntil[resource]) {
+		c.blockedUntil[resource] = retryAt
+	}
+	c.rateMu.Unlock()
+
+	return &RateLimitError{RetryAt: retryAt, Message: msg}
+}
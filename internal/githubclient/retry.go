@@ -0,0 +1,90 @@
+package githubclient
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed request is retried before
+	// the error is surfaced.
+	DefaultMaxRetries = 3
+
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 8 * time.Second
+)
+
+// SetMaxRetries overrides how many times transient failures are retried; 0
+// disables retries.
+func (c *Client) SetMaxRetries(n int) {
+	if n >= 0 {
+		c.maxRetries = n
+	}
+}
+
+// do sends req, retrying 5xx responses, timeouts and dropped connections with
+// exponential backoff and jitter. Only idempotent requests should pass retry;
+// writes are sent once so a rerun can't be triggered twice. Waiting between
+// attempts stops as soon as the request's context is done.
+func (c *Client) do(req *http.Request, retry bool) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := c.httpClient.Do(req)
+		if !retry || attempt >= c.maxRetries || !retryable(req, res, err) {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(io.Discard, io.LimitReader(res.Body, 4<<10))
+			res.Body.Close()
+		}
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return nil, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(c.retryDelay(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay doubles from the base delay on each attempt, capped, and picks
+// a random point in the upper half so clients don't retry in lockstep.
+func (c *Client) retryDelay(attempt int) time.Duration {
+	delay := min(c.retryBaseDelay<<attempt, maxRetryDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func retryable(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout() ||
+			errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SetAllowWrite opts the client into the POST endpoints below. The client is
+// read-only by default so a stray key press can't touch anyone's CI.
+func (c *Client) SetAllowWrite(allow bool) {
+	c.allowWrite = allow
+}
+
+// RerunRun re-runs every job of a workflow run as a new attempt.
+func (c *Client) RerunRun(ctx context.Context, owner, repo string, runID int64) error {
+	return c.post(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/rerun", owner, repo, runID))
+}
+
+// RerunFailedJobs re-runs only the failed jobs (and their dependents) of a
+// workflow run as a new attempt.
+func (c *Client) RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error {
+	return c.post(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/rerun-failed-jobs", owner, repo, runID))
+}
+
+// CancelRun cancels a queued or in-progress workflow run.
+func (c *Client) CancelRun(ctx context.Context, owner, repo string, runID int64) error {
+	return c.post(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/cancel", owner, repo, runID))
+}
+
+func (c *Client) post(ctx context.Context, path string) error {
+	if !c.allowWrite {
+		return ErrWriteDisabled
+	}
+	if err := c.checkBackoff(resourceCore, time.Now()); err != nil {
+		return err
+	}
+
+	target, err := c.resolveURL(path, nil)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, target)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(req, false)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	c.recordRateLimit(res.Header)
+
+	if res.StatusCode >= 400 {
+		return c.responseError(res)
+	}
+	return nil
+}
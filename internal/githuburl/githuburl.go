@@ -4,10 +4,90 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// DefaultHost is the public GitHub host. Parsed values with an empty Host
+// (e.g. ones persisted before enterprise support) refer to it.
+const DefaultHost = "github.com"
+
+var (
+	hostsMu         sync.RWMutex
+	enterpriseHosts = map[string]bool{}
+
+	defaultRepoMu sync.RWMutex
+	defaultRepo   Parsed
+)
+
+// SetEnterpriseHosts configures the GitHub Enterprise Server hostnames that
+// Parse accepts in addition to github.com.
+func SetEnterpriseHosts(hosts ...string) {
+	next := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" && host != DefaultHost {
+			next[host] = true
+		}
+	}
+	hostsMu.Lock()
+	enterpriseHosts = next
+	hostsMu.Unlock()
+}
+
+// SetDefaultRepo configures the repository that short forms without one
+// (#123 and plain run IDs) refer to. An empty owner or repo clears it.
+func SetDefaultRepo(host, owner, repo string) {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" {
+		host = DefaultHost
+	}
+	next := Parsed{Host: host, Owner: owner, Repo: repo}
+	if owner == "" || repo == "" {
+		next = Parsed{}
+	}
+	defaultRepoMu.Lock()
+	defaultRepo = next
+	defaultRepoMu.Unlock()
+}
+
+// DefaultRepo returns the repository set by SetDefaultRepo, if any.
+func DefaultRepo() (host, owner, repo string, ok bool) {
+	defaultRepoMu.RLock()
+	defer defaultRepoMu.RUnlock()
+	return defaultRepo.Host, defaultRepo.Owner, defaultRepo.Repo, defaultRepo.Owner != ""
+}
+
+// ParseRepo parses an owner/repo or host/owner/repo reference, as accepted by
+// the --repo flag.
+func ParseRepo(raw string) (host, owner, repo string, err error) {
+	parts := strings.Split(strings.Trim(strings.TrimSpace(raw), "/"), "/")
+	switch {
+	case len(parts) == 2:
+		host = DefaultHost
+		owner, repo = parts[0], parts[1]
+	case len(parts) == 3:
+		host, owner, repo = strings.ToLower(parts[0]), parts[1], parts[2]
+	default:
+		return "", "", "", fmt.Errorf("repository must be owner/repo or host/owner/repo, got %q", raw)
+	}
+	if owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("repository must be owner/repo or host/owner/repo, got %q", raw)
+	}
+	return host, owner, strings.TrimSuffix(repo, ".git"), nil
+}
+
+func supportedHost(host string) bool {
+	if host == DefaultHost {
+		return true
+	}
+	hostsMu.RLock()
+	defer hostsMu.RUnlock()
+	return enterpriseHosts[host]
+}
+
 // Kind identifies the type of GitHub URL provided by the user.
 type Kind int
 
@@ -16,47 +96,127 @@ const (
 	KindWorkflowRun
 	KindPullRequest
 	KindCommit
+	KindBranch
+	// KindActions is a repository's Actions page, optionally filtered by the
+	// page's search query (workflow:, branch:, actor:, event:, is:).
+	KindActions
+	// KindWorkflow is a single workflow's page (actions/workflows/<file>).
+	KindWorkflow
+	// KindCheckRun is a check run page (<owner>/<repo>/runs/<id>). For Actions
+	// the check run ID is the job ID, which resolves to its workflow run.
+	KindCheckRun
 )
 
 // Parsed represents a GitHub URL that the watcher understands.
 type Parsed struct {
 	Kind     Kind
+	Host     string
 	Owner    string
 	Repo     string
 	RunID    int64
+	JobID    int64
 	PRNumber int
 	SHA      string
+	Branch   string
+	Query    string
+	Workflow string
 	RawURL   string
 }
 
 func (p Parsed) String() string {
+	if p.Kind == KindUnknown {
+		return "unknown"
+	}
+	repo := fmt.Sprintf("%s/%s", p.Owner, p.Repo)
+	if p.IsEnterprise() {
+		repo = fmt.Sprintf("%s/%s", p.Host, repo)
+	}
+	return fmt.Sprintf("%s %s", repo, p.Label())
+}
+
+// Label describes the target within its repository, e.g. "PR #12" or
+// "branch main".
+func (p Parsed) Label() string {
 	switch p.Kind {
 	case KindWorkflowRun:
-		return fmt.Sprintf("%s/%s run %d", p.Owner, p.Repo, p.RunID)
+		if p.JobID != 0 {
+			return fmt.Sprintf("run %d job %d", p.RunID, p.JobID)
+		}
+		return fmt.Sprintf("run %d", p.RunID)
+	case KindCheckRun:
+		return fmt.Sprintf("check run %d", p.JobID)
 	case KindPullRequest:
-		return fmt.Sprintf("%s/%s PR #%d", p.Owner, p.Repo, p.PRNumber)
+		return fmt.Sprintf("PR #%d", p.PRNumber)
 	case KindCommit:
-		return fmt.Sprintf("%s/%s commit %.7s", p.Owner, p.Repo, p.SHA)
+		return fmt.Sprintf("commit %.7s", p.SHA)
+	case KindBranch:
+		return fmt.Sprintf("branch %s", p.Branch)
+	case KindActions:
+		if p.Query != "" {
+			return fmt.Sprintf("actions (%s)", p.Query)
+		}
+		return "actions"
+	case KindWorkflow:
+		return fmt.Sprintf("workflow %s", p.Workflow)
 	default:
 		return "unknown"
 	}
 }
 
-// Parse converts a user provided GitHub URL into a structured value that the
-// application can work with.
+// Key identifies the target independently of how it was written, so the same
+// PR or branch pasted twice (or as a URL and a shorthand) is fetched once.
+func (p Parsed) Key() string {
+	host := p.Host
+	if host == "" {
+		host = DefaultHost
+	}
+	repo := strings.ToLower(fmt.Sprintf("%s/%s/%s", host, p.Owner, p.Repo))
+	switch p.Kind {
+	case KindWorkflowRun:
+		return fmt.Sprintf("%s/run/%d", repo, p.RunID)
+	case KindCheckRun:
+		return fmt.Sprintf("%s/check/%d", repo, p.JobID)
+	case KindPullRequest:
+		return fmt.Sprintf("%s/pull/%d", repo, p.PRNumber)
+	case KindCommit:
+		return fmt.Sprintf("%s/commit/%s", repo, strings.ToLower(p.SHA))
+	case KindBranch:
+		return fmt.Sprintf("%s/tree/%s", repo, p.Branch)
+	case KindActions:
+		return fmt.Sprintf("%s/actions?%s", repo, strings.Join(strings.Fields(p.Query), " "))
+	case KindWorkflow:
+		return fmt.Sprintf("%s/workflow/%s", repo, strings.ToLower(p.Workflow))
+	default:
+		return repo
+	}
+}
+
+// IsEnterprise reports whether the URL points at a GitHub Enterprise Server
+// host rather than github.com.
+func (p Parsed) IsEnterprise() bool {
+	return p.Host != "" && p.Host != DefaultHost
+}
+
+// Parse converts a user provided GitHub URL or short form (see
+// parseShorthand) into a structured value that the application can work with.
 func Parse(raw string) (Parsed, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return Parsed{}, fmt.Errorf("empty URL")
 	}
 
+	if parsed, ok, err := parseShorthand(raw); ok {
+		return parsed, err
+	}
+
 	u, err := url.Parse(raw)
 	if err != nil {
 		return Parsed{}, fmt.Errorf("invalid URL: %w", err)
 	}
 
-	if u.Host != "github.com" {
-		return Parsed{}, fmt.Errorf("only github.com URLs are supported")
+	host := strings.ToLower(u.Host)
+	if !supportedHost(host) {
+		return Parsed{}, fmt.Errorf("unsupported host %q (only github.com and configured enterprise hosts are supported)", u.Host)
 	}
 
 	segments := splitPath(u.Path)
@@ -65,6 +225,7 @@ func Parse(raw string) (Parsed, error) {
 	}
 
 	parsed := Parsed{
+		Host:   host,
 		Owner:  segments[0],
 		Repo:   segments[1],
 		RawURL: raw,
@@ -81,6 +242,33 @@ func Parse(raw string) (Parsed, error) {
 		}
 		parsed.Kind = KindWorkflowRun
 		parsed.RunID = id
+		// .../runs/<id>/job/<job_id>, possibly under /attempts/<n>.
+		for i := 5; i+1 < len(segments); i++ {
+			if segments[i] != "job" {
+				continue
+			}
+			jobID, err := strconv.ParseInt(segments[i+1], 10, 64)
+			if err != nil {
+				return Parsed{}, fmt.Errorf("invalid job id: %w", err)
+			}
+			parsed.JobID = jobID
+		}
+	case len(segments) >= 4 && segments[2] == "runs":
+		id, err := strconv.ParseInt(segments[3], 10, 64)
+		if err != nil {
+			return Parsed{}, fmt.Errorf("invalid check run id: %w", err)
+		}
+		parsed.Kind = KindCheckRun
+		parsed.JobID = id
+	case len(segments) >= 4 && segments[2] == "actions" && segments[3] == "workflows":
+		if len(segments) < 5 {
+			return Parsed{}, fmt.Errorf("workflow URL missing file name")
+		}
+		parsed.Kind = KindWorkflow
+		parsed.Workflow = segments[4]
+	case len(segments) == 3 && segments[2] == "actions":
+		parsed.Kind = KindActions
+		parsed.Query = strings.TrimSpace(u.Query().Get("query"))
 	case len(segments) >= 4 && segments[2] == "pull":
 		num, err := strconv.Atoi(segments[3])
 		if err != nil {
@@ -94,6 +282,10 @@ func Parse(raw string) (Parsed, error) {
 		}
 		parsed.Kind = KindCommit
 		parsed.SHA = segments[3]
+	case len(segments) >= 4 && segments[2] == "tree":
+		// Branch names may contain slashes: /tree/feature/login.
+		parsed.Kind = KindBranch
+		parsed.Branch = strings.Join(segments[3:], "/")
 	default:
 		return Parsed{}, fmt.Errorf("unsupported GitHub URL path: %s", path.Join(segments...))
 	}
@@ -101,6 +293,81 @@ func Parse(raw string) (Parsed, error) {
 	return parsed, nil
 }
 
+var (
+	repoShorthand = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)/([A-Za-z0-9._-]+)(?:([#@])(\S+))?$`)
+	prShorthand   = regexp.MustCompile(`^#([0-9]+)$`)
+	runShorthand  = regexp.MustCompile(`^[0-9]+$`)
+	commitSHA     = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+)
+
+// parseShorthand handles the short forms accepted in the input box:
+//
+//	owner/repo#123      pull request
+//	owner/repo@<sha>    commit (7-40 hex characters)
+//	owner/repo@branch   branch
+//	#123                pull request in the default repository
+//	123456              workflow run in the default repository
+//
+// owner/repo forms refer to github.com. The second result is false when raw
+// is not a short form; an error is returned for short forms that can't be
+// resolved, such as #123 without a default repository.
+func parseShorthand(raw string) (Parsed, bool, error) {
+	if match := repoShorthand.FindStringSubmatch(raw); match != nil && match[3] != "" {
+		parsed := Parsed{Host: DefaultHost, Owner: match[1], Repo: match[2], RawURL: raw}
+		return shorthandTarget(parsed, match[3], match[4])
+	}
+
+	if prShorthand.MatchString(raw) || runShorthand.MatchString(raw) {
+		host, owner, repo, ok := DefaultRepo()
+		if !ok {
+			return Parsed{}, true, fmt.Errorf("%s needs a repository: run ghwatch inside a clone or pass --repo owner/repo", raw)
+		}
+		if !supportedHost(host) {
+			return Parsed{}, true, fmt.Errorf("default repository host %q is not a configured enterprise host", host)
+		}
+		parsed := Parsed{Host: host, Owner: owner, Repo: repo, RawURL: raw}
+		if strings.HasPrefix(raw, "#") {
+			return shorthandTarget(parsed, "#", raw[1:])
+		}
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return Parsed{}, true, fmt.Errorf("invalid run id: %w", err)
+		}
+		parsed.Kind = KindWorkflowRun
+		parsed.RunID = id
+		return parsed, true, nil
+	}
+
+	return Parsed{}, false, nil
+}
+
+// IsRepoShorthand reports whether raw is a short form that names its own
+// repository (owner/repo#123 or owner/repo@ref), as opposed to the ones
+// resolved against the default repository.
+func IsRepoShorthand(raw string) bool {
+	match := repoShorthand.FindStringSubmatch(strings.TrimSpace(raw))
+	return match != nil && match[3] != ""
+}
+
+func shorthandTarget(parsed Parsed, sep, ref string) (Parsed, bool, error) {
+	switch {
+	case sep == "#":
+		num, err := strconv.Atoi(ref)
+		if err != nil {
+			return Parsed{}, true, fmt.Errorf("invalid pull request number: %w", err)
+		}
+		parsed.Kind = KindPullRequest
+		parsed.PRNumber = num
+	case commitSHA.MatchString(ref):
+		parsed.Kind = KindCommit
+		parsed.SHA = ref
+	default:
+		parsed.Kind = KindBranch
+		parsed.Branch = ref
+	}
+	return parsed, true, nil
+}
+
 func splitPath(p string) []string {
 	parts := strings.Split(p, "/")
 	out := make([]string, 0, len(parts))
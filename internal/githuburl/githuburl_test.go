@@ -18,6 +18,28 @@ func TestParseWorkflowRun(t *testing.T) {
 	}
 }
 
+func TestParseJobAndCheckRun(t *testing.T) {
+	parsed, err := Parse("https://github.com/owner/repo/actions/runs/123/job/456")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindWorkflowRun || parsed.RunID != 123 || parsed.JobID != 456 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	parsed, err = Parse("https://github.com/owner/repo/runs/789?check_suite_focus=true")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindCheckRun || parsed.JobID != 789 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	if _, err := Parse("https://github.com/owner/repo/actions/runs/123/job/abc"); err == nil {
+		t.Fatal("expected error for invalid job id")
+	}
+}
+
 func TestParsePullRequest(t *testing.T) {
 	url := "https://github.com/owner/repo/pull/42"
 	parsed, err := Parse(url)
@@ -40,6 +62,60 @@ func TestParseCommit(t *testing.T) {
 	}
 }
 
+func TestParseBranch(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/owner/repo/tree/main":          "main",
+		"https://github.com/owner/repo/tree/feature/login": "feature/login",
+		"owner/repo@release/1.x":                           "release/1.x",
+	}
+	for raw, branch := range cases {
+		parsed, err := Parse(raw)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", raw, err)
+		}
+		if parsed.Kind != KindBranch || parsed.Owner != "owner" || parsed.Repo != "repo" || parsed.Branch != branch {
+			t.Fatalf("Parse(%q) = %#v", raw, parsed)
+		}
+		if parsed.Host != DefaultHost {
+			t.Fatalf("Parse(%q) host = %q", raw, parsed.Host)
+		}
+	}
+
+	url, _ := Parse("https://github.com/Owner/Repo/tree/main")
+	short, _ := Parse("owner/repo@main")
+	if url.Key() != short.Key() {
+		t.Fatalf("expected URL and shorthand to share a key, got %q and %q", url.Key(), short.Key())
+	}
+}
+
+func TestParseActionsFeed(t *testing.T) {
+	parsed, err := Parse("https://github.com/owner/repo/actions?query=workflow%3ACI+branch%3Amain")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindActions || parsed.Query != "workflow:CI branch:main" {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	parsed, err = Parse("https://github.com/owner/repo/actions")
+	if err != nil || parsed.Kind != KindActions || parsed.Query != "" {
+		t.Fatalf("unexpected parsed result: %#v (%v)", parsed, err)
+	}
+}
+
+func TestParseWorkflow(t *testing.T) {
+	parsed, err := Parse("https://github.com/owner/repo/actions/workflows/deploy.yml?query=branch%3Amain")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindWorkflow || parsed.Workflow != "deploy.yml" {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+	if _, err := Parse("https://github.com/owner/repo/actions/workflows"); err == nil {
+		t.Fatal("expected error for workflow URL without a file")
+	}
+}
+
 func TestParseInvalidHost(t *testing.T) {
 	_, err := Parse("https://example.com/owner/repo")
 	if err == nil {
@@ -53,3 +129,102 @@ func TestParseUnsupportedPath(t *testing.T) {
 		t.Fatal("expected error for unsupported path")
 	}
 }
+
+func TestParseEnterpriseHost(t *testing.T) {
+	SetEnterpriseHosts("ghe.example.com")
+	defer SetEnterpriseHosts()
+
+	parsed, err := Parse("https://ghe.example.com/owner/repo/pull/7")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Host != "ghe.example.com" || parsed.Kind != KindPullRequest || parsed.PRNumber != 7 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+	if !parsed.IsEnterprise() {
+		t.Fatal("expected enterprise URL to report IsEnterprise")
+	}
+
+	if _, err := Parse("https://other.example.com/owner/repo/pull/7"); err == nil {
+		t.Fatal("expected error for unconfigured host")
+	}
+}
+
+func TestParseShortForms(t *testing.T) {
+	SetDefaultRepo("", "", "")
+	t.Cleanup(func() { SetDefaultRepo("", "", "") })
+
+	parsed, err := Parse("owner/repo#42")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindPullRequest || parsed.Owner != "owner" || parsed.Repo != "repo" || parsed.PRNumber != 42 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	parsed, err = Parse("owner/repo@0123abc")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindCommit || parsed.SHA != "0123abc" {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	for _, raw := range []string{"#42", "123456"} {
+		if _, err := Parse(raw); err == nil {
+			t.Fatalf("expected error for %q without a default repository", raw)
+		}
+	}
+
+	SetDefaultRepo("GHE.example.com", "corp", "app")
+	if _, err := Parse("#42"); err == nil {
+		t.Fatal("expected error for a default repository on an unknown host")
+	}
+
+	SetEnterpriseHosts("ghe.example.com")
+	t.Cleanup(func() { SetEnterpriseHosts() })
+	parsed, err = Parse("#42")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindPullRequest || parsed.Host != "ghe.example.com" || parsed.Owner != "corp" || parsed.PRNumber != 42 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+
+	parsed, err = Parse("123456")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.Kind != KindWorkflowRun || parsed.Repo != "app" || parsed.RunID != 123456 {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+}
+
+func TestIsRepoShorthand(t *testing.T) {
+	for raw, want := range map[string]bool{
+		"owner/repo#42":      true,
+		"owner/repo@main":    true,
+		"#42":                false,
+		"123456":             false,
+		"owner/repo":         false,
+		"https://github.com": false,
+	} {
+		if got := IsRepoShorthand(raw); got != want {
+			t.Fatalf("IsRepoShorthand(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	host, owner, repo, err := ParseRepo("owner/repo")
+	if err != nil || host != DefaultHost || owner != "owner" || repo != "repo" {
+		t.Fatalf("ParseRepo = %q %q %q %v", host, owner, repo, err)
+	}
+	host, owner, repo, err = ParseRepo("ghe.example.com/corp/app.git")
+	if err != nil || host != "ghe.example.com" || owner != "corp" || repo != "app" {
+		t.Fatalf("ParseRepo = %q %q %q %v", host, owner, repo, err)
+	}
+	if _, _, _, err := ParseRepo("repo"); err == nil {
+		t.Fatal("expected error for a bare repo name")
+	}
+}
@@ -0,0 +1,208 @@
+// Package gitrepo reads just enough of a local git checkout, without shelling
+// out to git, to tell which GitHub repository and commit it is on.
+package gitrepo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when no git directory exists at or above the
+// starting directory.
+var ErrNotFound = errors.New("not a git repository")
+
+// Repo is a local git checkout.
+type Repo struct {
+	// GitDir holds the checkout's own state (HEAD). For linked worktrees it
+	// differs from CommonDir.
+	GitDir string
+	// CommonDir holds the state shared by all worktrees (config, refs).
+	CommonDir string
+}
+
+// Find locates the git repository containing dir, walking up to the
+// filesystem root.
+func Find(dir string) (Repo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Repo{}, err
+	}
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		info, err := os.Stat(gitPath)
+		if err == nil {
+			if info.IsDir() {
+				return Repo{GitDir: gitPath, CommonDir: gitPath}, nil
+			}
+			return linkedRepo(dir, gitPath)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Repo{}, ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// linkedRepo follows a .git file ("gitdir: <path>"), as written for linked
+// worktrees and submodules.
+func linkedRepo(dir, gitFile string) (Repo, error) {
+	data, err := os.ReadFile(gitFile)
+	if err != nil {
+		return Repo{}, err
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return Repo{}, fmt.Errorf("unexpected .git file in %s", dir)
+	}
+	gitDir := resolve(dir, strings.TrimSpace(target))
+
+	repo := Repo{GitDir: gitDir, CommonDir: gitDir}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		repo.CommonDir = resolve(gitDir, strings.TrimSpace(string(common)))
+	}
+	return repo, nil
+}
+
+func resolve(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, p)
+}
+
+// RemoteURLs returns the URL of every remote in the repository config, keyed
+// by remote name.
+func (r Repo) RemoteURLs() (map[string]string, error) {
+	f, err := os.Open(filepath.Join(r.CommonDir, "config"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	remotes := map[string]string{}
+	remote := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			remote = ""
+			section := strings.Trim(line, "[]")
+			if name, ok := strings.CutPrefix(section, "remote "); ok {
+				remote = strings.Trim(strings.TrimSpace(name), `"`)
+			}
+			continue
+		}
+		if remote == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "url") {
+			if _, seen := remotes[remote]; !seen {
+				remotes[remote] = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+	}
+	return remotes, scanner.Err()
+}
+
+// Head returns the checked-out branch and commit. branch is empty for a
+// detached HEAD; sha is empty for a branch without commits yet.
+func (r Repo) Head() (branch, sha string, err error) {
+	data, err := os.ReadFile(filepath.Join(r.GitDir, "HEAD"))
+	if err != nil {
+		return "", "", err
+	}
+	head := strings.TrimSpace(string(data))
+	ref, ok := strings.CutPrefix(head, "ref:")
+	if !ok {
+		return "", head, nil
+	}
+	ref = strings.TrimSpace(ref)
+	branch = strings.TrimPrefix(ref, "refs/heads/")
+	sha, err = r.resolveRef(ref)
+	return branch, sha, err
+}
+
+// resolveRef looks a ref up as a loose file first, then in packed-refs.
+func (r Repo) resolveRef(ref string) (string, error) {
+	if data, err := os.ReadFile(filepath.Join(r.CommonDir, filepath.FromSlash(ref))); err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	f, err := os.Open(filepath.Join(r.CommonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		sha, name, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == ref {
+			return sha, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// GitHubRepo returns the GitHub repository the checkout's remotes point at,
+// preferring origin, then upstream, then any other remote.
+func (r Repo) GitHubRepo() (host, owner, name string, err error) {
+	remotes, err := r.RemoteURLs()
+	if err != nil {
+		return "", "", "", err
+	}
+	for _, remote := range []string{"origin", "upstream"} {
+		if host, owner, name, ok := ParseRemote(remotes[remote]); ok {
+			return host, owner, name, nil
+		}
+	}
+	for _, remoteURL := range remotes {
+		if host, owner, name, ok := ParseRemote(remoteURL); ok {
+			return host, owner, name, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("no GitHub remote in %s", r.CommonDir)
+}
+
+// ParseRemote extracts host, owner and repository from a remote URL in any of
+// the forms git accepts: https://host/owner/repo.git, ssh://git@host/owner/repo
+// and git@host:owner/repo.git.
+func ParseRemote(remote string) (host, owner, name string, ok bool) {
+	var repoPath string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", "", "", false
+		}
+		host, repoPath = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:path
+		hostPart, pathPart, found := strings.Cut(remote, ":")
+		if !found || strings.Contains(hostPart, "/") {
+			return "", "", "", false
+		}
+		if _, after, hasUser := strings.Cut(hostPart, "@"); hasUser {
+			hostPart = after
+		}
+		host, repoPath = hostPart, pathPart
+	}
+
+	parts := strings.Split(strings.Trim(repoPath, "/"), "/")
+	if host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", false
+	}
+	return strings.ToLower(host), parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
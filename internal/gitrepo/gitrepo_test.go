@@ -0,0 +1,111 @@
+package gitrepo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	cases := map[string][3]string{
+		"https://github.com/owner/repo.git":           {"github.com", "owner", "repo"},
+		"https://token@github.com/owner/repo":         {"github.com", "owner", "repo"},
+		"ssh://git@ghe.example.com:2222/corp/app.git": {"ghe.example.com", "corp", "app"},
+		"git@github.com:owner/repo.git":               {"github.com", "owner", "repo"},
+	}
+	for remote, want := range cases {
+		host, owner, name, ok := ParseRemote(remote)
+		if !ok || [3]string{host, owner, name} != want {
+			t.Fatalf("ParseRemote(%q) = %q %q %q %v", remote, host, owner, name, ok)
+		}
+	}
+	for _, remote := range []string{"", "/srv/git/repo.git", "https://github.com/owner"} {
+		if _, _, _, ok := ParseRemote(remote); ok {
+			t.Fatalf("expected ParseRemote(%q) to fail", remote)
+		}
+	}
+}
+
+func TestFindReadsRemotesFromParentDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".git", "config"), `[core]
+	bare = false
+[remote "fork"]
+	url = git@github.com:me/repo.git
+[remote "origin"]
+	url = https://github.com/owner/repo.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+`)
+	sub := filepath.Join(root, "internal", "app")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Find(sub)
+	if err != nil {
+		t.Fatalf("Find returned error: %v", err)
+	}
+	host, owner, name, err := repo.GitHubRepo()
+	if err != nil {
+		t.Fatalf("GitHubRepo returned error: %v", err)
+	}
+	if host != "github.com" || owner != "owner" || name != "repo" {
+		t.Fatalf("GitHubRepo = %q %q %q", host, owner, name)
+	}
+}
+
+func TestFindFollowsWorktreeLinks(t *testing.T) {
+	root := t.TempDir()
+	main := filepath.Join(root, "main")
+	writeFile(t, filepath.Join(main, ".git", "config"), "[remote \"origin\"]\n\turl = git@github.com:owner/repo.git\n")
+	writeFile(t, filepath.Join(main, ".git", "worktrees", "wt", "commondir"), "../..\n")
+	wt := filepath.Join(root, "wt")
+	writeFile(t, filepath.Join(wt, ".git"), "gitdir: "+filepath.Join(main, ".git", "worktrees", "wt")+"\n")
+
+	repo, err := Find(wt)
+	if err != nil {
+		t.Fatalf("Find returned error: %v", err)
+	}
+	if repo.CommonDir != filepath.Join(main, ".git") {
+		t.Fatalf("CommonDir = %q", repo.CommonDir)
+	}
+	if _, owner, _, err := repo.GitHubRepo(); err != nil || owner != "owner" {
+		t.Fatalf("GitHubRepo owner = %q, err = %v", owner, err)
+	}
+}
+
+func TestHeadResolvesLooseAndPackedRefs(t *testing.T) {
+	root := t.TempDir()
+	gitDir := filepath.Join(root, ".git")
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/feature/login\n")
+	writeFile(t, filepath.Join(gitDir, "packed-refs"), "# pack-refs with: peeled fully-peeled sorted\n"+
+		"1111111111111111111111111111111111111111 refs/heads/feature/login\n"+
+		"2222222222222222222222222222222222222222 refs/heads/main\n")
+	repo := Repo{GitDir: gitDir, CommonDir: gitDir}
+
+	branch, sha, err := repo.Head()
+	if err != nil || branch != "feature/login" || sha != "1111111111111111111111111111111111111111" {
+		t.Fatalf("Head = %q %q %v", branch, sha, err)
+	}
+
+	// A loose ref is newer than the packed one.
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "feature", "login"), "3333333333333333333333333333333333333333\n")
+	if _, sha, _ := repo.Head(); sha != "3333333333333333333333333333333333333333" {
+		t.Fatalf("expected the loose ref to win, got %q", sha)
+	}
+
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "4444444444444444444444444444444444444444\n")
+	if branch, sha, err := repo.Head(); err != nil || branch != "" || sha != "4444444444444444444444444444444444444444" {
+		t.Fatalf("detached Head = %q %q %v", branch, sha, err)
+	}
+}
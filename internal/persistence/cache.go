@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nateberkopec/ghwatch/internal/githubclient"
+)
+
+type httpCacheData struct {
+	Version int                       `json:"version"`
+	Entries []githubclient.CacheEntry `json:"entries"`
+	SavedAt time.Time                 `json:"saved_at"`
+}
+
+const httpCacheVersion = 1
+
+func httpCachePath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "http_cache.json"), nil
+}
+
+// SaveHTTPCache writes the GitHub conditional-request cache next to runs.json so
+// the next session can revalidate with ETags instead of refetching.
+func SaveHTTPCache(entries []githubclient.CacheEntry) error {
+	path, err := httpCachePath()
+	if err != nil {
+		return err
+	}
+
+	cache := httpCacheData{
+		Version: httpCacheVersion,
+		Entries: entries,
+		SavedAt: time.Now(),
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal http cache: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadHTTPCache reads the cache saved by SaveHTTPCache. A missing file yields
+// an empty cache.
+func LoadHTTPCache() ([]githubclient.CacheEntry, error) {
+	path, err := httpCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read http cache file: %w", err)
+	}
+
+	var cache httpCacheData
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal http cache: %w", err)
+	}
+
+	if cache.Version != httpCacheVersion {
+		return nil, fmt.Errorf("unsupported http cache version: %d", cache.Version)
+	}
+
+	return cache.Entries, nil
+}
@@ -18,6 +18,8 @@ type trackedRunData struct {
 	Source     githuburl.Parsed         `json:"source"`
 	AddedAt    time.Time                `json:"added_at"`
 	ArchivedAt time.Time                `json:"archived_at"`
+	Attempts   []watch.Attempt          `json:"attempts,omitempty"`
+	Superseded bool                     `json:"superseded,omitempty"`
 }
 
 type stateData struct {
@@ -66,6 +68,8 @@ func convertToData(runs []*watch.TrackedRun) []trackedRunData {
 			Source:     run.Source,
 			AddedAt:    run.AddedAt,
 			ArchivedAt: run.ArchivedAt,
+			Attempts:   run.Attempts,
+			Superseded: run.Superseded,
 		})
 	}
 	return data
@@ -79,6 +83,8 @@ func convertFromData(data []trackedRunData) []*watch.TrackedRun {
 			Source:     d.Source,
 			AddedAt:    d.AddedAt,
 			ArchivedAt: d.ArchivedAt,
+			Attempts:   d.Attempts,
+			Superseded: d.Superseded,
 		})
 	}
 	return runs
@@ -207,3 +207,30 @@ func TestTimestampPreservation(t *testing.T) {
 		t.Errorf("expected AddedAt %v, got %v", addedAt, loadedRuns[0].AddedAt)
 	}
 }
+
+func TestHTTPCacheRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.Setenv("XDG_DATA_HOME", tmpDir)
+	defer os.Unsetenv("XDG_DATA_HOME")
+
+	entries := []githubclient.CacheEntry{
+		{
+			URL:      "https://api.github.com/repos/test/repo/actions/runs/1",
+			ETag:     `"abc"`,
+			Body:     []byte(`{"id":1}`),
+			StoredAt: time.Now(),
+		},
+	}
+
+	if err := SaveHTTPCache(entries); err != nil {
+		t.Fatalf("SaveHTTPCache failed: %v", err)
+	}
+
+	loaded, err := LoadHTTPCache()
+	if err != nil {
+		t.Fatalf("LoadHTTPCache failed: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ETag != `"abc"` || string(loaded[0].Body) != `{"id":1}` {
+		t.Fatalf("unexpected cache contents: %#v", loaded)
+	}
+}
@@ -22,6 +22,45 @@ type TrackedRun struct {
 	Source     githuburl.Parsed
 	AddedAt    time.Time
 	ArchivedAt time.Time
+	// Attempts holds the outcomes of earlier attempts, oldest first. Run
+	// always reflects the latest attempt.
+	Attempts []Attempt
+	// Superseded is set on PR runs for a commit that is no longer the PR's
+	// head, e.g. after a force-push.
+	Superseded bool
+}
+
+// Attempt is the final state of a superseded run attempt.
+type Attempt struct {
+	Number       int
+	Status       githubclient.RunStatus
+	StatusDetail string
+	UpdatedAt    time.Time
+}
+
+// PreviousAttempt returns the attempt before the current one, if any.
+func (r *TrackedRun) PreviousAttempt() *Attempt {
+	if len(r.Attempts) == 0 {
+		return nil
+	}
+	return &r.Attempts[len(r.Attempts)-1]
+}
+
+// update swaps in the latest run data, keeping the old state in Attempts when
+// GitHub reports a new attempt (e.g. after a rerun).
+func (r *TrackedRun) update(run githubclient.WorkflowRun) {
+	// Runs saved before attempts were tracked load with Attempt 0; that was
+	// their first attempt.
+	previous := max(r.Run.Attempt, 1)
+	if run.Attempt > previous {
+		r.Attempts = append(r.Attempts, Attempt{
+			Number:       previous,
+			Status:       r.Run.Status,
+			StatusDetail: r.Run.StatusDetail,
+			UpdatedAt:    r.Run.LastUpdatedAt,
+		})
+	}
+	r.Run = run
 }
 
 // ExportState returns a snapshot of the tracker state for persistence.
@@ -68,7 +107,7 @@ func NewTracker() *Tracker {
 func (t *Tracker) Upsert(run githubclient.WorkflowRun, source githuburl.Parsed) (newRun bool, statusChanged bool) {
 	if existing, ok := t.active[run.ID]; ok {
 		statusChanged = existing.Run.Status != run.Status
-		existing.Run = run
+		existing.update(run)
 		if existing.Source.Kind == githuburl.KindUnknown && source.Kind != githuburl.KindUnknown {
 			existing.Source = source
 		}
@@ -77,7 +116,7 @@ func (t *Tracker) Upsert(run githubclient.WorkflowRun, source githuburl.Parsed)
 
 	if existing, ok := t.archived[run.ID]; ok {
 		statusChanged = existing.Run.Status != run.Status
-		existing.Run = run
+		existing.update(run)
 		if existing.Source.Kind == githuburl.KindUnknown && source.Kind != githuburl.KindUnknown {
 			existing.Source = source
 		}
@@ -98,6 +137,37 @@ func (t *Tracker) Upsert(run githubclient.WorkflowRun, source githuburl.Parsed)
 	return true, false
 }
 
+// UpsertNew is Upsert for feeds that list many runs, most of them old. Runs
+// already tracked are refreshed (archived ones stay archived), and unknown
+// runs are only added when their ID is above since, normally LatestID of the
+// feed's source taken before the batch.
+func (t *Tracker) UpsertNew(run githubclient.WorkflowRun, source githuburl.Parsed, since int64) (newRun bool, statusChanged bool) {
+	if existing, ok := t.archived[run.ID]; ok {
+		statusChanged = existing.Run.Status != run.Status
+		existing.update(run)
+		return false, statusChanged
+	}
+	if _, ok := t.active[run.ID]; !ok && run.ID <= since {
+		return false, false
+	}
+	return t.Upsert(run, source)
+}
+
+// LatestID returns the highest run ID tracked, active or archived, for runs
+// whose source has the given key. GitHub run IDs grow over time, so this marks
+// how far a feed has been read.
+func (t *Tracker) LatestID(sourceKey string) int64 {
+	var latest int64
+	for _, runs := range []map[int64]*TrackedRun{t.active, t.archived} {
+		for id, run := range runs {
+			if id > latest && run.Source.Key() == sourceKey {
+				latest = id
+			}
+		}
+	}
+	return latest
+}
+
 // Archive moves a run out of the active list.
 func (t *Tracker) Archive(id int64) bool {
 	run, ok := t.active[id]
@@ -112,6 +182,51 @@ func (t *Tracker) Archive(id int64) bool {
 	return true
 }
 
+// MarkSuperseded compares the runs added from a pull request source with its
+// current head, source.SHA; other sources are ignored. Runs for other commits
+// are flagged as superseded; runs for the head are unflagged and take source,
+// so the tracked head moves with the PR. It returns the IDs of active runs
+// that were newly superseded.
+func (t *Tracker) MarkSuperseded(source githuburl.Parsed) []int64 {
+	if source.Kind != githuburl.KindPullRequest || source.SHA == "" {
+		return nil
+	}
+	key := source.Key()
+	var superseded []int64
+	for _, runs := range []map[int64]*TrackedRun{t.active, t.archived} {
+		for id, run := range runs {
+			if run.Source.Key() != key {
+				continue
+			}
+			if run.Run.HeadSHA == source.SHA {
+				run.Superseded = false
+				run.Source = source
+				continue
+			}
+			if !run.Superseded {
+				run.Superseded = true
+				if _, active := t.active[id]; active {
+					superseded = append(superseded, id)
+				}
+			}
+		}
+	}
+	slices.Sort(superseded)
+	return superseded
+}
+
+// ArchiveSource archives every active run added from the source with the
+// given key and returns how many were moved.
+func (t *Tracker) ArchiveSource(sourceKey string) int {
+	archived := 0
+	for _, id := range append([]int64(nil), t.activeOrder...) {
+		if run := t.active[id]; run != nil && run.Source.Key() == sourceKey && t.Archive(id) {
+			archived++
+		}
+	}
+	return archived
+}
+
 // Unarchive moves a run back to the active list.
 func (t *Tracker) Unarchive(id int64) bool {
 	run, ok := t.archived[id]
@@ -125,6 +240,14 @@ func (t *Tracker) Unarchive(id int64) bool {
 	return true
 }
 
+// Get looks a run up by ID in either the active or archived list.
+func (t *Tracker) Get(id int64) *TrackedRun {
+	if run, ok := t.active[id]; ok {
+		return run
+	}
+	return t.archived[id]
+}
+
 // VisibleRuns returns the runs in display order.
 func (t *Tracker) VisibleRuns(showArchived bool) []*TrackedRun {
 	if showArchived {
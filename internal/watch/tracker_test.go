@@ -99,3 +99,138 @@ func TestTrackerVisibleRunsOrder(t *testing.T) {
 		t.Fatalf("expected newest run first, got %v", order)
 	}
 }
+
+func TestTrackerKeepsAttemptHistory(t *testing.T) {
+	tracker := NewTracker()
+	run := githubclient.WorkflowRun{
+		ID:           7,
+		Name:         "test",
+		RepoFullName: "owner/repo",
+		Status:       githubclient.RunStatusFailed,
+		StatusDetail: "completed/failure",
+		Attempt:      1,
+	}
+	tracker.Upsert(run, githuburl.Parsed{})
+
+	run.Attempt = 2
+	run.Status = githubclient.RunStatusPending
+	run.StatusDetail = "queued"
+	_, changed := tracker.Upsert(run, githuburl.Parsed{})
+	if !changed {
+		t.Fatal("expected new attempt to register as a status change")
+	}
+
+	tracked := tracker.VisibleRuns(false)[0]
+	if tracked.Run.Attempt != 2 {
+		t.Fatalf("expected latest attempt to be current, got %d", tracked.Run.Attempt)
+	}
+	prev := tracked.PreviousAttempt()
+	if prev == nil || prev.Number != 1 || prev.Status != githubclient.RunStatusFailed {
+		t.Fatalf("expected failed first attempt in history, got %#v", tracked.Attempts)
+	}
+
+	// Refreshing the same attempt must not grow the history.
+	run.Status = githubclient.RunStatusSuccess
+	tracker.Upsert(run, githuburl.Parsed{})
+	if len(tracked.Attempts) != 1 {
+		t.Fatalf("expected one historical attempt, got %d", len(tracked.Attempts))
+	}
+
+	// Runs persisted before attempts were tracked have Attempt 0.
+	legacy := githubclient.WorkflowRun{ID: 8, Status: githubclient.RunStatusFailed}
+	tracker.Upsert(legacy, githuburl.Parsed{})
+	legacy.Attempt = 2
+	legacy.Status = githubclient.RunStatusPending
+	tracker.Upsert(legacy, githuburl.Parsed{})
+	if prev := tracker.Get(8).PreviousAttempt(); prev == nil || prev.Number != 1 || prev.Status != githubclient.RunStatusFailed {
+		t.Fatalf("expected the legacy run's first attempt in history, got %#v", tracker.Get(8).Attempts)
+	}
+}
+
+func TestTrackerDistinguishesTerminalStates(t *testing.T) {
+	tracker := NewTracker()
+	run := githubclient.WorkflowRun{ID: 3, RepoFullName: "owner/repo", Status: githubclient.RunStatusFailed}
+	tracker.Upsert(run, githuburl.Parsed{})
+
+	run.Status = githubclient.RunStatusCancelled
+	if _, changed := tracker.Upsert(run, githuburl.Parsed{}); !changed {
+		t.Fatal("expected failed -> cancelled to count as a change")
+	}
+
+	run.Status = githubclient.RunStatusCancelled
+	if _, changed := tracker.Upsert(run, githuburl.Parsed{}); changed {
+		t.Fatal("expected identical status to not count as a change")
+	}
+}
+
+func TestTrackerUpsertNewOnlyAddsNewerRuns(t *testing.T) {
+	tracker := NewTracker()
+	feed := githuburl.Parsed{Kind: githuburl.KindActions, Host: githuburl.DefaultHost, Owner: "owner", Repo: "repo"}
+
+	tracker.Upsert(githubclient.WorkflowRun{ID: 10, Status: githubclient.RunStatusPending}, feed)
+	tracker.Upsert(githubclient.WorkflowRun{ID: 12, Status: githubclient.RunStatusPending}, feed)
+	tracker.Archive(12)
+
+	since := tracker.LatestID(feed.Key())
+	if since != 12 {
+		t.Fatalf("expected the archived run to count towards the mark, got %d", since)
+	}
+
+	for _, run := range []githubclient.WorkflowRun{
+		{ID: 13, Status: githubclient.RunStatusPending},
+		{ID: 12, Status: githubclient.RunStatusSuccess},
+		{ID: 11, Status: githubclient.RunStatusSuccess},
+		{ID: 10, Status: githubclient.RunStatusFailed},
+	} {
+		tracker.UpsertNew(run, feed, since)
+	}
+
+	if got := tracker.IDs(false); len(got) != 2 || got[0] != 13 || got[1] != 10 {
+		t.Fatalf("expected runs 13 and 10 to be active, got %v", got)
+	}
+	if tracker.Get(11) != nil {
+		t.Fatal("expected the older untracked run to be ignored")
+	}
+	if archived := tracker.Get(12); archived == nil || archived.Run.Status != githubclient.RunStatusSuccess || tracker.LenArchived() != 1 {
+		t.Fatal("expected the archived run to be refreshed without reviving it")
+	}
+}
+
+func TestTrackerMarkSupersededFollowsPullRequestHead(t *testing.T) {
+	tracker := NewTracker()
+	pr := githuburl.Parsed{Kind: githuburl.KindPullRequest, Owner: "owner", Repo: "repo", PRNumber: 7}
+
+	oldHead := pr
+	oldHead.SHA = "aaa"
+	tracker.Upsert(githubclient.WorkflowRun{ID: 1, HeadSHA: "aaa", Status: githubclient.RunStatusFailed}, oldHead)
+	tracker.Upsert(githubclient.WorkflowRun{ID: 2, HeadSHA: "aaa", Status: githubclient.RunStatusSuccess}, oldHead)
+	tracker.Archive(2)
+	if got := tracker.MarkSuperseded(oldHead); len(got) != 0 {
+		t.Fatalf("expected nothing superseded on the same head, got %v", got)
+	}
+
+	newHead := pr
+	newHead.SHA = "bbb"
+	tracker.Upsert(githubclient.WorkflowRun{ID: 3, HeadSHA: "bbb", Status: githubclient.RunStatusPending}, newHead)
+	if got := tracker.MarkSuperseded(newHead); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected active run 1 to be newly superseded, got %v", got)
+	}
+	if !tracker.Get(2).Superseded || tracker.Get(3).Superseded {
+		t.Fatal("expected old-head runs flagged and the new head's run not")
+	}
+	if got := tracker.MarkSuperseded(newHead); len(got) != 0 {
+		t.Fatalf("expected already superseded runs not to be reported again, got %v", got)
+	}
+
+	// Force-pushing back to the old head makes its runs current again.
+	tracker.MarkSuperseded(oldHead)
+	if tracker.Get(1).Superseded || tracker.Get(1).Source.SHA != "aaa" || !tracker.Get(3).Superseded {
+		t.Fatal("expected the old head's runs to be current again")
+	}
+
+	commit := githuburl.Parsed{Kind: githuburl.KindCommit, Owner: "owner", Repo: "repo", SHA: "abc1234"}
+	tracker.Upsert(githubclient.WorkflowRun{ID: 4, HeadSHA: "abc1234def"}, commit)
+	if got := tracker.MarkSuperseded(commit); len(got) != 0 || tracker.Get(4).Superseded {
+		t.Fatal("expected commit sources to be ignored")
+	}
+}